@@ -0,0 +1,168 @@
+//go:build js && wasm
+
+// Command wasm runs the tapoo game engine inside the browser. It is built with
+//
+//	GOOS=js GOARCH=wasm go build -o web/static/tapoo.wasm ./cmd/wasm
+//
+// and registers the tapooStart function used by web/static/wasm.html to play a game.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sync"
+	"syscall/js"
+	"time"
+
+	"github.com/dmigwi/tapoo/game"
+	"github.com/dmigwi/tapoo/web"
+)
+
+// frameInterval defines the shortest time between two frames drawn by the browser.
+const frameInterval = 50 * time.Millisecond
+
+// errStopped is returned by Poll once the page stops sending the player actions.
+var errStopped = errors.New("the game was stopped by the page")
+
+// browser defines the frontend that hands the frames to a JavaScript function and receives
+// the player actions from the page. Only the latest frame drawn is handed over once every
+// frame interval.
+type browser struct {
+	columns, rows int
+	draw          js.Value
+	events        chan game.Event
+
+	mu      sync.Mutex
+	latest  *game.Frame
+	pending chan struct{}
+}
+
+// Size returns the number of columns and rows of text that the page can display.
+func (b *browser) Size() (columns, rows int) {
+	return b.columns, b.rows
+}
+
+// Draw queues the provided frame to be drawn by the page.
+func (b *browser) Draw(frame game.Frame) error {
+	b.mu.Lock()
+	b.latest = &frame
+	b.mu.Unlock()
+
+	select {
+	case b.pending <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Poll waits for the next player action sent by the page.
+func (b *browser) Poll() (game.Event, error) {
+	if event, ok := <-b.events; ok {
+		return event, nil
+	}
+	return game.Event{}, errStopped
+}
+
+// flush hands the latest frame drawn to the page, frames identical to the one drawn
+// before are skipped.
+func (b *browser) flush(previous []byte) []byte {
+	b.mu.Lock()
+	frame := b.latest
+	b.mu.Unlock()
+
+	if frame == nil {
+		return previous
+	}
+
+	data, err := web.EncodeFrame(*frame)
+	if err != nil || bytes.Equal(data, previous) {
+		return previous
+	}
+
+	b.draw.Invoke(string(data))
+
+	return data
+}
+
+// render hands the frames drawn to the page until the provided channel is closed.
+func (b *browser) render(done chan struct{}) {
+	var previous []byte
+
+	for {
+		select {
+		case <-done:
+			b.flush(previous)
+			return
+
+		case <-b.pending:
+			previous = b.flush(previous)
+			time.Sleep(frameInterval)
+		}
+	}
+}
+
+// start plays a game with the options provided as JSON by the page. The page also provides
+// the number of columns and rows of text it can display, the function drawing the frames and
+// the function called with an error message, empty if none, once the game ends. A function
+// sending the player actions as JSON, e.g. {"action":"move","direction":"LEFT"}, is returned.
+func start(this js.Value, args []js.Value) interface{} {
+	var opts game.Options
+
+	if len(args) < 5 {
+		return js.Null()
+	}
+
+	frontend := &browser{
+		columns: args[1].Int(),
+		rows:    args[2].Int(),
+		draw:    args[3],
+		events:  make(chan game.Event, 16),
+		pending: make(chan struct{}, 1),
+	}
+
+	end := args[4]
+
+	go func() {
+		done := make(chan struct{})
+		go frontend.render(done)
+
+		err := json.Unmarshal([]byte(args[0].String()), &opts)
+		if err == nil {
+			err = game.Play(opts, frontend)
+		}
+
+		close(done)
+
+		if err != nil {
+			end.Invoke(string(web.EncodeError(err)))
+			return
+		}
+
+		end.Invoke("")
+	}()
+
+	return js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) == 0 {
+			return nil
+		}
+
+		if event, err := web.DecodeEvent([]byte(args[0].String())); err == nil {
+			// Actions sent faster than the game handles them are dropped.
+			select {
+			case frontend.events <- event:
+			default:
+			}
+		}
+
+		return nil
+	})
+}
+
+func main() {
+	js.Global().Set("tapooStart", js.FuncOf(start))
+
+	// The engine keeps running for as long as the page is open.
+	select {}
+}
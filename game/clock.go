@@ -0,0 +1,65 @@
+package game
+
+import "time"
+
+// clock defines the source of time used by the game loop and the scores. Tests replace the
+// real clock with one that only moves forward when told to.
+type clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) timer
+	NewTimer(d time.Duration) timer
+}
+
+// timer defines a ticker or a timer created by a clock. Stop does not drain the channel.
+type timer interface {
+	C() <-chan time.Time
+	Stop()
+	Reset(d time.Duration)
+}
+
+// stopTimer stops the provided timer and drops the expiry it already sent, if any. A timer
+// must be stopped this way before it is reset, otherwise an old expiry can still be received.
+func stopTimer(t timer) {
+	t.Stop()
+
+	select {
+	case <-t.C():
+	default:
+	}
+}
+
+// realClock defines the clock that follows the system time.
+type realClock struct{}
+
+// Now returns the current system time.
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTicker returns a ticker sending the time on its channel after every period d.
+func (realClock) NewTicker(d time.Duration) timer {
+	return realTicker{time.NewTicker(d)}
+}
+
+// NewTimer returns a timer sending the time on its channel once d has elapsed.
+func (realClock) NewTimer(d time.Duration) timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+// realTicker defines a ticker following the system time.
+type realTicker struct {
+	ticker *time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time   { return t.ticker.C }
+func (t realTicker) Stop()                 { t.ticker.Stop() }
+func (t realTicker) Reset(d time.Duration) { t.ticker.Reset(d) }
+
+// realTimer defines a timer following the system time.
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time   { return t.timer.C }
+func (t realTimer) Stop()                 { t.timer.Stop() }
+func (t realTimer) Reset(d time.Duration) { t.timer.Reset(d) }
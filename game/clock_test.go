@@ -0,0 +1,182 @@
+package game
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// fakeClock defines a clock that only moves forward when it is advanced. Its tickers and
+// timers fire, at most once per advance, once the time they wait for is reached.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) timer {
+	return c.addTimer(d, d)
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) timer {
+	return c.addTimer(d, 0)
+}
+
+func (c *fakeClock) addTimer(d, period time.Duration) *fakeTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{clock: c, c: make(chan time.Time, 1), deadline: c.now.Add(d), period: period, active: true}
+	c.timers = append(c.timers, t)
+
+	return t
+}
+
+// Advance moves the clock forward by the provided duration and fires the tickers and the
+// timers whose time is reached.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	for _, t := range c.timers {
+		if !t.active || t.deadline.After(c.now) {
+			continue
+		}
+
+		select {
+		case t.c <- c.now:
+		default:
+		}
+
+		if t.period == 0 {
+			t.active = false
+			continue
+		}
+
+		for !t.deadline.After(c.now) {
+			t.deadline = t.deadline.Add(t.period)
+		}
+	}
+}
+
+// waitActive waits for the provided number of tickers and timers to be running.
+func (c *fakeClock) waitActive(count int) bool {
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); {
+		c.mu.Lock()
+		active := 0
+		for _, t := range c.timers {
+			if t.active {
+				active++
+			}
+		}
+		c.mu.Unlock()
+
+		if active == count {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return false
+}
+
+// fakeTimer defines a ticker, if it has a period, or a timer of the fake clock.
+type fakeTimer struct {
+	clock    *fakeClock
+	c        chan time.Time
+	deadline time.Time
+	period   time.Duration
+	active   bool
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	t.active = false
+}
+
+func (t *fakeTimer) Reset(d time.Duration) {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	t.deadline = t.clock.now.Add(d)
+	t.active = true
+
+	if t.period > 0 {
+		t.period = d
+	}
+}
+
+// TestRealClock tests the functionality of realClock
+func TestRealClock(t *testing.T) {
+	Convey("TestRealClock: Given the real clock", t, func() {
+		var clock clock = realClock{}
+
+		Convey("its ticker should keep ticking until it is stopped", func() {
+			ticker := clock.NewTicker(time.Millisecond)
+
+			first, second := <-ticker.C(), <-ticker.C()
+			ticker.Stop()
+
+			So(second.After(first), ShouldBeTrue)
+		})
+
+		Convey("its timer should fire once the duration it is reset to elapses", func() {
+			start := clock.Now()
+			timer := clock.NewTimer(time.Hour)
+
+			timer.Reset(5 * time.Millisecond)
+
+			So((<-timer.C()).Sub(start), ShouldBeGreaterThanOrEqualTo, 5*time.Millisecond)
+		})
+	})
+}
+
+// TestStopTimer tests the functionality of stopTimer
+func TestStopTimer(t *testing.T) {
+	Convey("TestStopTimer: Given a timer that has already fired", t, func() {
+		clock := newFakeClock()
+		timer := clock.NewTimer(time.Second)
+
+		clock.Advance(time.Second)
+
+		Convey("the expiry should be dropped once it is stopped and reset", func() {
+			stopTimer(timer)
+			timer.Reset(time.Minute)
+
+			expired := false
+
+			select {
+			case <-timer.C():
+				expired = true
+			default:
+			}
+
+			So(expired, ShouldBeFalse)
+
+			clock.Advance(time.Minute)
+
+			_, ok := <-timer.C()
+			So(ok, ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,244 @@
+package game
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dmigwi/tapoo/maze"
+)
+
+// Action defines what the player wants to do.
+type Action int
+
+const (
+	// Move moves the player, or the target while it is being hidden, in the event direction.
+	Move Action = iota
+
+	// Hint displays the shortest path to the target.
+	Hint
+
+	// Pause stops the game.
+	Pause
+
+	// Proceed continues the game after it is stopped.
+	Proceed
+
+	// Quit ends the game once it is stopped.
+	Quit
+
+	// Select hides the target on the current cell while the target is being hidden.
+	Select
+
+	// Travel moves the player along the shortest path to the cell clicked, a step at a time.
+	// While the target is being hidden, the target is moved to the cell clicked instead.
+	Travel
+
+	// Drop leaves a breadcrumb on the cell of the player or picks it up if one is already there.
+	Drop
+)
+
+// Event defines an action of the player captured by a frontend. Direction is only used by
+// the Move action while Row and X are only used by the Travel action. They hold the row and
+// the number of characters before the character clicked on the floor displayed.
+type Event struct {
+	Action    Action
+	Direction string
+	Row       int
+	X         int
+}
+
+// State defines what a frame shows.
+type State int
+
+const (
+	// Playing frames show the player looking for the target.
+	Playing State = iota
+
+	// Hiding frames show the target being moved to its hiding place.
+	Hiding
+
+	// Paused frames show the game after the player stops it.
+	Paused
+
+	// Won frames show the game after the player locates the target on time.
+	Won
+
+	// Lost frames show the game after the time to locate the target runs out.
+	Lost
+
+	// Beaten frames show the game after a computer seeker locates the target first.
+	Beaten
+)
+
+// Frame defines everything displayed by a frontend at a given moment. Elements holds the parts
+// of the floor of the player, with the parts the player cannot see replaced by blank spaces.
+// Floor starts from zero. Player, Target, Seekers and Hint hold the rows and the columns of the
+// cells among the elements, only the visible ones on the displayed floor are included. Trail
+// holds the cells the player has visited and Breadcrumbs the cells the player has marked, both
+// are shown even outside the visibility radius. TimeLeft and TimeBudget hold the seconds left
+// and the seconds allowed, time bonuses included, while BestScore is zero until the level is won.
+// Name holds the name of the player, if any.
+type Frame struct {
+	State       State
+	Elements    []maze.Element
+	Floor       int
+	Floors      int
+	Player      []int
+	Target      []int
+	Seekers     [][]int
+	Hint        [][]int
+	Trail       [][]int
+	Breadcrumbs [][]int
+	Name        string
+	Level       int
+	TimeLeft    int
+	TimeBudget  int
+	Moves       int
+	Scores      int
+	BestScore   int
+	Hints       int
+}
+
+// Frontend defines how the game is displayed to the player and how the player actions are
+// captured. Size returns the number of columns and rows available to display the maze and Poll
+// waits for the next player action. An error from Poll ends the game.
+type Frontend interface {
+	Size() (columns, rows int)
+	Draw(frame Frame) error
+	Poll() (Event, error)
+}
+
+// isPointVisible checks if the provided point is within the visibility radius (in cells)
+// of the player position. A radius of zero means that the whole maze is visible. The wrap
+// size holds the rows and the columns after which a wrap-around maze repeats, so that the
+// points just across an edge are visible too. It is nil if the maze does not wrap around.
+func isPointVisible(playerPos []int, row, column, radius int, wrapSize []int) bool {
+	if radius <= 0 {
+		return true
+	}
+
+	for index, point := range []int{row, column} {
+		distance := point - playerPos[index]
+		if distance < 0 {
+			distance = -distance
+		}
+
+		if len(wrapSize) > index && wrapSize[index] > 0 && wrapSize[index]-distance < distance {
+			distance = wrapSize[index] - distance
+		}
+
+		if distance > 2*radius+1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hideMaze returns a copy of the parts of a maze floor where the walls and paths outside
+// the visibility radius of the player are replaced with blank spaces. Without a player
+// position the whole floor is hidden.
+func hideMaze(playerPos []int, radius int, wrapSize []int, elements []maze.Element) []maze.Element {
+	view := make([]maze.Element, len(elements))
+
+	for index, element := range elements {
+		if playerPos == nil || !isPointVisible(playerPos, element.Row, element.Column, radius, wrapSize) {
+			element.Text = strings.Repeat(" ", utf8.RuneCountInString(element.Text))
+			element.Kind = maze.Plain
+		}
+
+		view[index] = element
+	}
+
+	return view
+}
+
+// getFrame returns the frame of the provided state showing the floor of the provided cell.
+// Only the parts within the visibility radius of the cell are shown, zero shows the whole floor.
+// While the target is being hidden, the provided cell is shown as the target. Paused frames
+// hide the whole maze so that pausing cannot be used to study it.
+func (s *session) getFrame(state State, cell, visibility int) Frame {
+	floor, row, column := s.m.Locate(cell)
+	center := []int{row, column}
+
+	rows, columns := s.m.WrapSize()
+	wrapSize := []int{rows, columns}
+
+	frame := Frame{
+		State:      state,
+		Elements:   hideMaze(center, visibility, wrapSize, s.m.Elements(floor)),
+		Floor:      floor,
+		Floors:     s.m.Floors(),
+		Name:       s.player,
+		Level:      s.level,
+		TimeLeft:   s.timeLeft,
+		TimeBudget: s.m.TimeBudget() + s.bonusTime,
+		Moves:      s.moves,
+		Scores:     s.scores,
+		BestScore:  s.bestScores[s.level],
+		Hints:      s.hints,
+	}
+
+	// locate returns the row and the column of the provided cell if it is found on the floor
+	// shown, the hint is shown even outside the visibility radius.
+	locate := func(cell, radius int) []int {
+		if cellFloor, row, column := s.m.Locate(cell); cellFloor == floor && isPointVisible(center, row, column, radius, wrapSize) {
+			return []int{row, column}
+		}
+		return nil
+	}
+
+	switch state {
+	case Hiding:
+		frame.Target = locate(cell, 0)
+		return frame
+
+	case Paused:
+		frame.Elements = hideMaze(nil, 0, nil, frame.Elements)
+		return frame
+	}
+
+	frame.Player = locate(s.m.Player(), 0)
+	frame.Target = locate(s.m.Target(), visibility)
+
+	for _, seeker := range s.m.Seekers() {
+		if pos := locate(seeker, visibility); pos != nil {
+			frame.Seekers = append(frame.Seekers, pos)
+		}
+	}
+
+	for _, step := range s.hintPath {
+		if pos := locate(step, 0); pos != nil {
+			frame.Hint = append(frame.Hint, pos)
+		}
+	}
+
+	for _, cell := range getSortedCells(s.visited) {
+		if pos := locate(cell, 0); pos != nil {
+			frame.Trail = append(frame.Trail, pos)
+		}
+	}
+
+	for _, cell := range getSortedCells(s.breadcrumbs) {
+		if pos := locate(cell, 0); pos != nil {
+			frame.Breadcrumbs = append(frame.Breadcrumbs, pos)
+		}
+	}
+
+	return frame
+}
+
+// getSortedCells returns the cells of the provided set in ascending order so that the frames
+// of an unchanged game are identical.
+func getSortedCells(set map[int]bool) []int {
+	cells := make([]int, 0, len(set))
+
+	for cell := range set {
+		cells = append(cells, cell)
+	}
+
+	sort.Ints(cells)
+
+	return cells
+}
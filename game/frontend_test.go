@@ -0,0 +1,118 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dmigwi/tapoo/maze"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestIsPointVisible tests the functionality of isPointVisible
+func TestIsPointVisible(t *testing.T) {
+	Convey("TestIsPointVisible: Given the visibility radius", t, func() {
+		Convey("of zero, every point should be visible", func() {
+			So(isPointVisible([]int{1, 1}, 40, 80, 0, nil), ShouldBeTrue)
+		})
+
+		Convey("of a single cell, only the points next to the player should be visible", func() {
+			So(isPointVisible([]int{5, 5}, 8, 2, 1, nil), ShouldBeTrue)
+			So(isPointVisible([]int{5, 5}, 9, 5, 1, nil), ShouldBeFalse)
+		})
+
+		Convey("on a wrap-around maze, the points just across the edges should be visible", func() {
+			So(isPointVisible([]int{1, 1}, 11, 23, 1, []int{12, 24}), ShouldBeTrue)
+			So(isPointVisible([]int{1, 1}, 11, 23, 1, nil), ShouldBeFalse)
+			So(isPointVisible([]int{1, 1}, 6, 1, 1, []int{12, 24}), ShouldBeFalse)
+		})
+	})
+}
+
+// TestGetFrame tests the functionality of getFrame
+func TestGetFrame(t *testing.T) {
+	Convey("TestGetFrame: Given the game state", t, func() {
+		m, err := maze.NewMaze(maze.Config{Length: 12, Width: 8})
+		So(err, ShouldBeNil)
+
+		s := &session{
+			m:           m,
+			scores:      300,
+			hints:       2,
+			hintPath:    m.Solve(),
+			visited:     map[int]bool{m.Target(): true, m.Player(): true},
+			breadcrumbs: map[int]bool{m.Player(): true},
+		}
+
+		Convey("of a game being played, the player, the target and the hint should be shown", func() {
+			frame := s.getFrame(Playing, m.Player(), 0)
+
+			_, row, column := m.Locate(m.Player())
+
+			So(frame.State, ShouldEqual, Playing)
+			So(frame.Player, ShouldResemble, []int{row, column})
+			So(frame.Target, ShouldNotBeNil)
+			So(frame.Hint, ShouldHaveLength, len(m.Solve()))
+			So(frame.Scores, ShouldEqual, 300)
+			So(frame.Hints, ShouldEqual, 2)
+			So(frame.Elements, ShouldHaveLength, len(m.Elements(0)))
+		})
+
+		Convey("of a level being played, the HUD values should be shown", func() {
+			s.level, s.timeLeft, s.moves, s.bonusTime = 2, 40, 7, 15
+			s.bestScores = map[int]int{2: 5100}
+
+			frame := s.getFrame(Playing, m.Player(), 0)
+
+			So(frame.Level, ShouldEqual, 2)
+			So(frame.TimeLeft, ShouldEqual, 40)
+			So(frame.TimeBudget, ShouldEqual, m.TimeBudget()+15)
+			So(frame.Moves, ShouldEqual, 7)
+			So(frame.BestScore, ShouldEqual, 5100)
+		})
+
+		Convey("of a player exploring the maze, the trail and the breadcrumbs should be shown", func() {
+			frame := s.getFrame(Playing, m.Player(), 1)
+
+			_, row, column := m.Locate(m.Player())
+			_, targetRow, targetColumn := m.Locate(m.Target())
+
+			So(frame.Trail, ShouldHaveLength, 2)
+			So(frame.Trail, ShouldContain, []int{row, column})
+			So(frame.Trail, ShouldContain, []int{targetRow, targetColumn})
+			So(frame.Breadcrumbs, ShouldResemble, [][]int{{row, column}})
+		})
+
+		Convey("of a game with a small visibility radius, the far parts should be hidden", func() {
+			frame := s.getFrame(Playing, m.Player(), 1)
+
+			for _, element := range frame.Elements {
+				if !isPointVisible(frame.Player, element.Row, element.Column, 1, nil) {
+					So(element.Text, ShouldNotContainSubstring, "-")
+				}
+			}
+		})
+
+		Convey("of a paused game, the whole maze and the pieces should be hidden", func() {
+			frame := s.getFrame(Paused, m.Player(), 0)
+
+			for _, element := range frame.Elements {
+				So(strings.TrimSpace(element.Text), ShouldBeEmpty)
+			}
+
+			So(frame.Player, ShouldBeNil)
+			So(frame.Target, ShouldBeNil)
+			So(frame.Trail, ShouldBeNil)
+			So(frame.Scores, ShouldEqual, 300)
+		})
+
+		Convey("of a target being hidden, the provided cell should be shown as the target", func() {
+			frame := s.getFrame(Hiding, m.Cells()[3], 0)
+
+			_, row, column := m.Locate(m.Cells()[3])
+
+			So(frame.Player, ShouldBeNil)
+			So(frame.Target, ShouldResemble, []int{row, column})
+			So(frame.Hint, ShouldBeNil)
+		})
+	})
+}
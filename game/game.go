@@ -0,0 +1,593 @@
+package game
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/dmigwi/tapoo/maze"
+)
+
+const (
+	// succeeded status is update ONLY when the player locates the target successfully.
+	succeeded = iota
+
+	// proceed status should be updated if the player wants to continue playing the game after:
+	// 1. They paused the game.
+	// 2. They successfully located the target on time and would like to play the next level.
+	// 3. They failed to locate on time and would like to play the level again
+	proceed
+
+	// failed status is updated after the player fails to locate the target of time.
+	failed
+
+	// beaten status is updated after a computer seeker locates the target before the player.
+	beaten
+
+	// pause status is updated after the play voluntarity stops the game.
+	pause
+
+	// Status should be updated after the player voluntarily paused the game or won the level
+	// or even failed to finish the level successfully.
+	quit
+
+	// closed status is updated after the frontend stops capturing the player actions.
+	closed
+)
+
+const (
+	// coinScore defines the scores awarded for every coin collected.
+	coinScore = 500
+
+	// timeBonus defines the number of seconds added for every time bonus collected.
+	timeBonus = 10
+
+	// seekerInterval defines the time taken by a computer seeker to make a single move.
+	seekerInterval = 350 * time.Millisecond
+
+	// tickInterval defines the time between two updates of the game while it is played.
+	tickInterval = 500 * time.Microsecond
+
+	// travelInterval defines the time taken by the player to make a single move while
+	// travelling to the cell clicked.
+	travelInterval = 80 * time.Millisecond
+)
+
+// Options defines the settings used to create and play the tapoo game levels.
+// Empty values use the defaults of the maze package.
+type Options struct {
+	// Difficulty defines the name of the difficulty preset.
+	Difficulty string
+
+	// Target defines the target placement strategy.
+	Target string
+
+	// MinDistance defines the shortest distance to the target, only used by the
+	// DistanceTarget strategy.
+	MinDistance int
+
+	// MovingTarget makes the target move through the maze hiding from the player.
+	MovingTarget bool
+
+	// Seekers defines the strategies of the computer seekers racing the player to the target.
+	Seekers []string
+
+	// Items places coins, time bonuses, keys and locked doors in the maze.
+	Items bool
+
+	// Teleporters places paired teleporters in the maze.
+	Teleporters bool
+
+	// OneWayGates places one-way gates in the maze.
+	OneWayGates bool
+
+	// Weave adds crossings where corridors pass over and under each other.
+	Weave bool
+
+	// Floors defines the number of maze floors joined by stairs, zero or one means a single floor.
+	Floors int
+
+	// Shape defines the name of a built-in maze shape or the path to a mask file.
+	Shape string
+
+	// Topology defines the name of the shape of the cells, weave mazes only support square cells.
+	Topology string
+
+	// Wrap joins the opposite maze edges, it is only supported by square cells.
+	Wrap bool
+
+	// AspectRatio defines the ratio of the maze length to its width, zero uses the
+	// aspect ratio of the terminal.
+	AspectRatio float64
+
+	// BestScores defines the best score of every level won in earlier games. It is updated
+	// once a level is won with a better score.
+	BestScores map[int]int
+
+	// Player defines the name of the player shown by the frontend.
+	Player string
+
+	// Record, if set, is called with the result of every level once it is over.
+	Record func(result Result)
+}
+
+// Result defines how a level played ended. State is either Won, Lost or Beaten while Seconds
+// holds the time spent playing, pauses excluded.
+type Result struct {
+	Player    string
+	Level     int
+	State     State
+	Seconds   int
+	Scores    int
+	Moves     int
+	HintsUsed int
+}
+
+// session defines the state of a game played through a frontend.
+type session struct {
+	m        *maze.Maze
+	frontend Frontend
+
+	level  int
+	player string
+	scores int
+
+	// timeLeft defines the seconds left to locate the target, time bonuses included.
+	timeLeft int
+
+	// moves defines the number of moves made by the player in the current level.
+	moves int
+
+	// bestScores defines the best score of every level won.
+	bestScores map[int]int
+
+	// hints defines the number of hints left for the current level.
+	hints int
+
+	// hintPath defines the cells on the shortest path to the target that are displayed
+	// after a hint is used. It is cleared once the player moves.
+	hintPath []int
+
+	// bonusScores defines the scores earned by collecting coins in the current level.
+	bonusScores int
+
+	// bonusTime defines the seconds added by collecting time bonuses in the current level.
+	bonusTime int
+
+	// travelPath defines the cells left on the way to the cell clicked by the player.
+	travelPath []int
+
+	// visited defines the cells the player has been on, they make up the trail displayed.
+	visited map[int]bool
+
+	// breadcrumbs defines the cells marked by the player to tell the explored corridors apart.
+	breadcrumbs map[int]bool
+
+	// paused is set while the game is stopped, either by the player or once the level is over.
+	// over is only set once the level is over, the game cannot be resumed then.
+	paused bool
+	over   bool
+
+	status chan int
+
+	// events passes the player actions captured by the frontend to the game loop, so that the
+	// game is only updated by a single goroutine.
+	events chan Event
+
+	// done is closed once the game ends so that no more status updates are waited for.
+	done chan struct{}
+}
+
+// setStatus updates the game status unless the game has already ended.
+func (s *session) setStatus(value int) {
+	select {
+	case s.status <- value:
+	case <-s.done:
+	}
+}
+
+// showHint displays the shortest path from the current player position to the
+// target if the player still has some hints left. If the target is locked behind a
+// door, the shortest path to the nearest key is displayed instead.
+func (s *session) showHint() {
+	if s.hints <= 0 {
+		return
+	}
+
+	s.hints--
+	s.hintPath = s.m.Hint()
+}
+
+// movePlayer moves the player in the provided direction and collects the item found on the
+// new cell. Coins and time bonuses update the bonus scores and the bonus time respectively.
+// The hint displayed is cleared once the player moves and the new cell joins the trail.
+func (s *session) movePlayer(direction string) {
+	player := s.m.Player()
+
+	switch s.m.Move(direction) {
+	case maze.Coin:
+		s.bonusScores += coinScore
+
+	case maze.TimeBonus:
+		s.bonusTime += timeBonus
+	}
+
+	if s.m.Player() != player {
+		s.moves++
+		s.hintPath = nil
+		s.visited[s.m.Player()] = true
+	}
+}
+
+// dropBreadcrumb leaves a breadcrumb on the cell of the player or picks up the one found there.
+func (s *session) dropBreadcrumb() {
+	if player := s.m.Player(); s.breadcrumbs[player] {
+		delete(s.breadcrumbs, player)
+	} else {
+		s.breadcrumbs[player] = true
+	}
+}
+
+// getClickedCell returns the cell found on the character clicked on the floor of the provided
+// cell. Zero is returned if the character clicked is not part of a cell.
+func (s *session) getClickedCell(cell int, event Event) int {
+	floor, _, _ := s.m.Locate(cell)
+
+	for _, element := range s.m.Elements(floor) {
+		if element.Row == event.Row && event.X >= element.X && event.X < element.X+utf8.RuneCountInString(element.Text) {
+			return s.m.CellAt(floor, element.Row, element.Column)
+		}
+	}
+
+	return 0
+}
+
+// travel makes a single move towards the cell clicked. The travel stops if the way is blocked,
+// e.g. by a door without its key.
+func (s *session) travel() {
+	if len(s.travelPath) == 0 {
+		return
+	}
+
+	next := s.travelPath[0]
+	direction := s.m.Direction(s.m.Player(), next)
+
+	if direction != "" {
+		s.movePlayer(direction)
+	}
+
+	if s.m.Player() != next {
+		s.travelPath = nil
+		return
+	}
+
+	s.travelPath = s.travelPath[1:]
+}
+
+// handleEvent updates the game depending on the action of the player. While the game is
+// paused only the actions that quit or resume the game are handled, pausing again resumes it.
+func (s *session) handleEvent(event Event) {
+	if s.paused && event.Action == Pause {
+		event.Action = Proceed
+	}
+
+	if s.paused && event.Action != Quit && event.Action != Proceed {
+		return
+	}
+
+	switch event.Action {
+	case Hint:
+		s.showHint()
+
+	case Quit:
+		go s.setStatus(quit)
+
+	case Proceed:
+		go s.setStatus(proceed)
+
+	case Pause:
+		go s.setStatus(pause)
+
+	case Drop:
+		s.dropBreadcrumb()
+
+	case Move:
+		s.travelPath = nil
+		s.movePlayer(event.Direction)
+
+	case Travel:
+		if path := s.m.PathTo(s.getClickedCell(s.m.Player(), event)); len(path) > 1 {
+			s.travelPath = path[1:]
+		}
+	}
+}
+
+// moveSeekers makes a single move for every computer seeker. The beaten status is
+// updated if any of the seekers locates the target.
+func (s *session) moveSeekers() {
+	if s.m.MoveSeekers() {
+		go s.setStatus(beaten)
+	}
+}
+
+// chooseTarget lets the hiding player move the target through the maze paths and select
+// the cell to hide it. The target cannot be hidden at the starting point. False is returned
+// if the player quits before hiding the target.
+func (s *session) chooseTarget() (bool, error) {
+	cell := s.m.Target()
+
+	for {
+		if err := s.frontend.Draw(s.getFrame(Hiding, cell, 0)); err != nil {
+			return false, err
+		}
+
+		event, err := s.frontend.Poll()
+		if err != nil {
+			return false, err
+		}
+
+		switch event.Action {
+		case Quit:
+			return false, nil
+
+		case Select:
+			if s.m.SetTarget(cell) == nil {
+				return true, nil
+			}
+
+		case Move:
+			cell = s.m.Step(cell, event.Direction)
+
+		case Travel:
+			if clicked := s.getClickedCell(cell, event); clicked != 0 {
+				cell = clicked
+			}
+		}
+	}
+}
+
+// handleInput passes all the player actions captured by the frontend to the game loop. The
+// game ends once the frontend fails to capture them, e.g. when the player disconnects.
+func (s *session) handleInput() {
+	for {
+		event, err := s.frontend.Poll()
+		if err != nil {
+			s.setStatus(closed)
+			return
+		}
+
+		select {
+		case s.events <- event:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Play plays the tapoo game through the provided frontend until the player quits.
+// The options provided select the difficulty preset and the target placement strategy
+// used to create the game levels. If the game cannot be created an error is returned.
+func Play(opts Options, frontend Frontend) error {
+	return play(opts, frontend, realClock{})
+}
+
+// play plays the tapoo game like Play does, the time limit, the scores and the moving
+// pieces follow the provided clock.
+func play(opts Options, frontend Frontend, clock clock) error {
+	level := 1
+	columns, rows := frontend.Size()
+
+	length, width, err := maze.LevelSize(level, opts.Difficulty, opts.Topology, columns, rows, opts.AspectRatio)
+	if err != nil {
+		return err
+	}
+
+	m, err := maze.NewMaze(maze.Config{
+		Length:      length,
+		Width:       width,
+		Floors:      opts.Floors,
+		Topology:    opts.Topology,
+		Wrap:        opts.Wrap,
+		Shape:       opts.Shape,
+		Difficulty:  opts.Difficulty,
+		Weave:       opts.Weave,
+		Target:      opts.Target,
+		MinDistance: opts.MinDistance,
+		Teleporters: opts.Teleporters,
+		OneWayGates: opts.OneWayGates,
+		Items:       opts.Items,
+	})
+	if err != nil {
+		return err
+	}
+
+	s := &session{
+		m:           m,
+		frontend:    frontend,
+		level:       level,
+		player:      opts.Player,
+		bestScores:  opts.BestScores,
+		visited:     map[int]bool{m.Player(): true},
+		breadcrumbs: map[int]bool{},
+		status:      make(chan int),
+		events:      make(chan Event),
+		done:        make(chan struct{}),
+	}
+	defer close(s.done)
+
+	if opts.Target == maze.ChosenTarget {
+		if hidden, err := s.chooseTarget(); !hidden || err != nil {
+			return err
+		}
+	}
+
+	if s.bestScores == nil {
+		s.bestScores = map[int]int{}
+	}
+
+	preset := m.Difficulty()
+	s.hints = preset.Hints
+	s.timeLeft = m.TimeBudget()
+
+	for _, strategy := range opts.Seekers {
+		if err := m.AddSeeker(strategy); err != nil {
+			return err
+		}
+	}
+
+	go s.handleInput()
+
+	var (
+		timer      = clock.NewTicker(tickInterval)
+		timeBudget = m.TimeBudget()
+		timeout    = clock.NewTimer(time.Duration(timeBudget) * time.Second)
+
+		// started holds the time the level started at while pausedFor holds the time spent
+		// paused, the time elapsed only counts the time spent playing.
+		started   = clock.Now()
+		pausedAt  time.Time
+		pausedFor time.Duration
+		elapsed   = func(now time.Time) time.Duration { return now.Sub(started) - pausedFor }
+
+		hiderInterval = getHiderInterval(level)
+		hiderMoved    = started
+		seekersMoved  = started
+		travelled     = started
+		appliedBonus  = s.bonusTime
+
+		// changed is set once the frame displayed is out of date. Frames are only built again
+		// then, not on every tick.
+		changed = true
+
+		// interrupt displays the whole floor of the player with the provided state.
+		interrupt = func(state State) error {
+			return frontend.Draw(s.getFrame(state, m.Player(), 0))
+		}
+
+		// stop freezes the clock of the level until it is resumed.
+		stop = func() {
+			timer.Stop()
+			stopTimer(timeout)
+
+			if !s.paused {
+				s.paused = true
+				pausedAt = clock.Now()
+			}
+		}
+
+		// end stops the level for good, records its result and displays the provided state.
+		end = func(state State) error {
+			stop()
+			s.over = true
+
+			if opts.Record != nil {
+				opts.Record(Result{
+					Player:    opts.Player,
+					Level:     level,
+					State:     state,
+					Seconds:   int(elapsed(pausedAt) / time.Second),
+					Scores:    s.scores,
+					Moves:     s.moves,
+					HintsUsed: preset.Hints - s.hints,
+				})
+			}
+
+			return interrupt(state)
+		}
+	)
+
+	for {
+		select {
+		case timeVal := <-timer.C():
+			if timeLeft := timeBudget + s.bonusTime - int(elapsed(timeVal)/time.Second); timeLeft != s.timeLeft {
+				s.timeLeft, changed = timeLeft, true
+			}
+			s.scores = s.timeLeft*100 + s.bonusScores
+
+			if s.bonusTime != appliedBonus {
+				stopTimer(timeout)
+				timeout.Reset(time.Duration(timeBudget+s.bonusTime)*time.Second - elapsed(timeVal))
+				appliedBonus = s.bonusTime
+			}
+
+			if opts.MovingTarget && timeVal.Sub(hiderMoved) >= hiderInterval {
+				m.MoveTarget()
+				hiderMoved, changed = timeVal, true
+			}
+
+			if timeVal.Sub(travelled) >= travelInterval {
+				changed = changed || len(s.travelPath) > 0
+				s.travel()
+				travelled = timeVal
+			}
+
+			if timeVal.Sub(seekersMoved) >= seekerInterval {
+				changed = changed || len(opts.Seekers) > 0
+				s.moveSeekers()
+				seekersMoved = timeVal
+			}
+
+			if changed {
+				if err := frontend.Draw(s.getFrame(Playing, m.Player(), preset.Visibility)); err != nil {
+					return err
+				}
+				changed = false
+			}
+
+			// check if target has been located
+			if m.Player() == m.Target() {
+				go s.setStatus(succeeded)
+			}
+
+		case <-timeout.C():
+			go s.setStatus(failed)
+
+		case event := <-s.events:
+			s.handleEvent(event)
+			changed = true
+
+		case returnedStatus := <-s.status:
+			switch {
+			case returnedStatus == closed, returnedStatus == quit && s.paused:
+				return nil
+
+			case s.over:
+				// The level is over, only quitting is left.
+
+			case returnedStatus == succeeded:
+				if s.scores > s.bestScores[level] {
+					s.bestScores[level] = s.scores
+				}
+
+				err = end(Won)
+
+			case returnedStatus == failed:
+				err = end(Lost)
+
+			case returnedStatus == beaten:
+				err = end(Beaten)
+
+			case returnedStatus == proceed && s.paused:
+				// Resume exactly where the game stopped, the moving pieces keep their pace.
+				now := clock.Now()
+				stopped := now.Sub(pausedAt)
+
+				pausedFor += stopped
+				hiderMoved = hiderMoved.Add(stopped)
+				seekersMoved = seekersMoved.Add(stopped)
+				travelled = travelled.Add(stopped)
+
+				s.paused, changed = false, true
+				timeout.Reset(time.Duration(timeBudget+s.bonusTime)*time.Second - elapsed(now))
+				timer.Reset(tickInterval)
+
+			case returnedStatus == pause && !s.paused:
+				stop()
+				err = interrupt(Paused)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
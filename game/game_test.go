@@ -0,0 +1,328 @@
+package game
+
+import (
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dmigwi/tapoo/maze"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// testFrontend defines a frontend whose player actions are sent through the events channel.
+// Poll fails once the events channel is closed.
+type testFrontend struct {
+	events chan Event
+
+	mu     sync.Mutex
+	frames []Frame
+}
+
+func newTestFrontend() *testFrontend {
+	return &testFrontend{events: make(chan Event)}
+}
+
+func (f *testFrontend) Size() (columns, rows int) {
+	return 120, 40
+}
+
+func (f *testFrontend) Draw(frame Frame) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.frames = append(f.frames, frame)
+
+	return nil
+}
+
+func (f *testFrontend) Poll() (Event, error) {
+	if event, ok := <-f.events; ok {
+		return event, nil
+	}
+	return Event{}, io.EOF
+}
+
+// last returns the latest frame drawn.
+func (f *testFrontend) last() Frame {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.frames) == 0 {
+		return Frame{State: -1}
+	}
+	return f.frames[len(f.frames)-1]
+}
+
+// count returns the number of frames drawn.
+func (f *testFrontend) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.frames)
+}
+
+// waitFor waits for a frame of the provided state to be drawn.
+func (f *testFrontend) waitFor(state State) bool {
+	return f.waitUntil(func(frame Frame) bool { return frame.State == state })
+}
+
+// waitUntil waits for a frame matching the provided condition to be drawn.
+func (f *testFrontend) waitUntil(matches func(frame Frame) bool) bool {
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); {
+		if matches(f.last()) {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return false
+}
+
+// TestPlay tests the functionality of Play
+func TestPlay(t *testing.T) {
+	Convey("TestPlay: Given a frontend", t, func() {
+		frontend := newTestFrontend()
+		ended := make(chan error)
+
+		Convey("the game should be paused and quit through its player actions", func() {
+			go func() { ended <- Play(Options{Difficulty: "easy"}, frontend) }()
+
+			So(frontend.waitFor(Playing), ShouldBeTrue)
+
+			frontend.events <- Event{Action: Pause}
+			So(frontend.waitFor(Paused), ShouldBeTrue)
+
+			frontend.events <- Event{Action: Quit}
+			So(<-ended, ShouldBeNil)
+		})
+
+		Convey("the game should end once the frontend stops capturing the player actions", func() {
+			go func() { ended <- Play(Options{Difficulty: "easy"}, frontend) }()
+
+			So(frontend.waitFor(Playing), ShouldBeTrue)
+
+			close(frontend.events)
+			So(<-ended, ShouldBeNil)
+		})
+
+		Convey("the hiding player should move the target before the game starts", func() {
+			go func() { ended <- Play(Options{Difficulty: "easy", Target: maze.ChosenTarget}, frontend) }()
+
+			So(frontend.waitFor(Hiding), ShouldBeTrue)
+			So(frontend.last().Player, ShouldBeNil)
+			So(frontend.last().Target, ShouldNotBeNil)
+
+			frontend.events <- Event{Action: Select}
+			So(frontend.waitFor(Playing), ShouldBeTrue)
+
+			close(frontend.events)
+			So(<-ended, ShouldBeNil)
+		})
+
+		Convey("that cannot display the maze, an error should be returned", func() {
+			So(Play(Options{Difficulty: "impossible"}, frontend), ShouldNotBeNil)
+		})
+	})
+}
+
+// TestPlayTiming tests the functionality of play
+func TestPlayTiming(t *testing.T) {
+	Convey("TestPlayTiming: Given a game following a fake clock", t, func() {
+		frontend := newTestFrontend()
+		clock := newFakeClock()
+		started := clock.Now()
+		ended := make(chan error)
+		results := make(chan Result, 1)
+
+		opts := Options{Difficulty: "easy", Player: "Ann", Record: func(result Result) { results <- result }}
+		go func() { ended <- play(opts, frontend, clock) }()
+
+		// The ticker and the time limit are running once the game starts.
+		So(clock.waitActive(2), ShouldBeTrue)
+
+		clock.Advance(tickInterval)
+		So(frontend.waitFor(Playing), ShouldBeTrue)
+
+		budget := frontend.last().TimeBudget
+
+		// timeLeft returns a condition matching the frames being played with the provided seconds left.
+		timeLeft := func(seconds int) func(frame Frame) bool {
+			return func(frame Frame) bool { return frame.State == Playing && frame.TimeLeft == seconds }
+		}
+
+		Convey("the scores should drop by a hundred every second", func() {
+			for _, seconds := range []int{1, 2, 5} {
+				clock.Advance(started.Add(time.Duration(seconds) * time.Second).Sub(clock.Now()))
+				So(frontend.waitUntil(timeLeft(budget-seconds)), ShouldBeTrue)
+				So(frontend.last().Scores, ShouldEqual, (budget-seconds)*100)
+			}
+		})
+
+		Convey("the frame should only be drawn again once it changes", func() {
+			drawn := frontend.count()
+
+			for i := 0; i < 20; i++ {
+				clock.Advance(tickInterval)
+				time.Sleep(time.Millisecond)
+			}
+
+			So(frontend.count(), ShouldEqual, drawn)
+
+			clock.Advance(started.Add(time.Second).Sub(clock.Now()))
+			So(frontend.waitUntil(timeLeft(budget-1)), ShouldBeTrue)
+			So(frontend.count(), ShouldEqual, drawn+1)
+		})
+
+		Convey("the level should be lost once the time runs out", func() {
+			clock.Advance(time.Duration(budget-1) * time.Second)
+			So(frontend.waitUntil(timeLeft(1)), ShouldBeTrue)
+
+			clock.Advance(time.Second)
+			So(frontend.waitFor(Lost), ShouldBeTrue)
+			So(frontend.last().Name, ShouldEqual, "Ann")
+
+			result := <-results
+
+			So(result.Player, ShouldEqual, "Ann")
+			So(result.State, ShouldEqual, Lost)
+			So(result.Seconds, ShouldEqual, budget)
+			So(result.Moves, ShouldEqual, 0)
+			So(result.HintsUsed, ShouldEqual, 0)
+		})
+
+		Convey("the time spent paused should not count", func() {
+			clock.Advance(2 * time.Second)
+			So(frontend.waitUntil(timeLeft(budget-2)), ShouldBeTrue)
+
+			frontend.events <- Event{Action: Pause}
+			So(frontend.waitFor(Paused), ShouldBeTrue)
+			So(clock.waitActive(0), ShouldBeTrue)
+
+			// The time limit cannot run out while the game is paused.
+			clock.Advance(time.Duration(budget) * time.Second)
+
+			frontend.events <- Event{Action: Proceed}
+			So(clock.waitActive(2), ShouldBeTrue)
+
+			clock.Advance(time.Second)
+			So(frontend.waitUntil(timeLeft(budget-3)), ShouldBeTrue)
+			So(frontend.last().Scores, ShouldEqual, (budget-3)*100)
+		})
+
+		close(frontend.events)
+		So(<-ended, ShouldBeNil)
+	})
+}
+
+// TestHandleEvent tests the functionality of handleEvent
+func TestHandleEvent(t *testing.T) {
+	Convey("TestHandleEvent: Given a player action", t, func() {
+		m, err := maze.NewMaze(maze.Config{Length: 6, Width: 5})
+		So(err, ShouldBeNil)
+
+		s := &session{
+			m:           m,
+			hints:       1,
+			visited:     map[int]bool{m.Player(): true},
+			breadcrumbs: map[int]bool{},
+			status:      make(chan int, 1),
+			done:        make(chan struct{}),
+		}
+
+		Convey("that uses a hint, the path to the target should be shown once", func() {
+			s.handleEvent(Event{Action: Hint})
+
+			So(s.hintPath, ShouldResemble, m.Solve())
+			So(s.hints, ShouldEqual, 0)
+
+			s.hintPath = nil
+			s.handleEvent(Event{Action: Hint})
+
+			So(s.hintPath, ShouldBeNil)
+		})
+
+		Convey("that moves the player, the hint should be cleared", func() {
+			s.handleEvent(Event{Action: Hint})
+
+			// Moving into a wall keeps the player in place.
+			for _, wall := range m.Walls(m.Player()) {
+				s.handleEvent(Event{Action: Move, Direction: wall})
+			}
+
+			So(s.hintPath, ShouldNotBeNil)
+
+			s.handleEvent(Event{Action: Move, Direction: m.Direction(m.Player(), m.Solve()[1])})
+
+			So(s.hintPath, ShouldBeNil)
+		})
+
+		Convey("that clicks a cell, the player should travel to it a move at a time", func() {
+			target := m.Target()
+			floor, row, column := m.Locate(target)
+
+			for _, element := range m.Elements(floor) {
+				if element.Row == row && element.Column == column {
+					s.handleEvent(Event{Action: Travel, Row: row, X: element.X + 1})
+				}
+			}
+
+			So(s.travelPath, ShouldResemble, m.Solve()[1:])
+
+			s.travel()
+
+			So(m.Player(), ShouldEqual, m.Solve()[0])
+			So(s.travelPath, ShouldHaveLength, len(m.Solve())-1)
+
+			for len(s.travelPath) > 0 {
+				s.travel()
+			}
+
+			So(m.Player(), ShouldEqual, target)
+		})
+
+		Convey("that clicks a wall or moves the player, the travel should stop", func() {
+			s.handleEvent(Event{Action: Travel, Row: 0, X: 0})
+			So(s.travelPath, ShouldBeNil)
+
+			s.travelPath = m.Solve()[1:]
+			s.handleEvent(Event{Action: Move, Direction: m.Direction(m.Player(), m.Solve()[1])})
+
+			So(s.travelPath, ShouldBeNil)
+		})
+
+		Convey("that moves the player, the cells left behind should join the trail", func() {
+			start := m.Player()
+			s.handleEvent(Event{Action: Move, Direction: m.Direction(start, m.Solve()[1])})
+
+			So(s.visited, ShouldResemble, map[int]bool{start: true, m.Player(): true})
+			So(s.moves, ShouldEqual, 1)
+		})
+
+		Convey("that drops a breadcrumb, it should be picked up by dropping it again", func() {
+			s.handleEvent(Event{Action: Drop})
+			So(s.breadcrumbs, ShouldResemble, map[int]bool{m.Player(): true})
+
+			s.handleEvent(Event{Action: Drop})
+			So(s.breadcrumbs, ShouldBeEmpty)
+		})
+
+		Convey("that pauses the game, the pause status should be updated", func() {
+			s.handleEvent(Event{Action: Pause})
+			So(<-s.status, ShouldEqual, pause)
+		})
+
+		Convey("of a paused game, only resuming or quitting should be handled", func() {
+			s.paused = true
+			player := m.Player()
+
+			s.handleEvent(Event{Action: Move, Direction: m.Direction(player, m.Solve()[1])})
+			s.handleEvent(Event{Action: Hint})
+
+			So(m.Player(), ShouldEqual, player)
+			So(s.hints, ShouldEqual, 1)
+
+			s.handleEvent(Event{Action: Pause})
+			So(<-s.status, ShouldEqual, proceed)
+		})
+	})
+}
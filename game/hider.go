@@ -0,0 +1,28 @@
+package game
+
+import "time"
+
+const (
+	// hiderMaxInterval defines the time taken by the moving target to make a single
+	// move in the training level (level 0).
+	hiderMaxInterval = 1200 * time.Millisecond
+
+	// hiderMinInterval defines the shortest time the moving target can take to make a
+	// single move regardless of the game level.
+	hiderMinInterval = 250 * time.Millisecond
+
+	// hiderSpeedUp defines how much faster the moving target gets in consecutive game levels.
+	hiderSpeedUp = 25 * time.Millisecond
+)
+
+// getHiderInterval returns the time taken by the moving target to make a single move
+// in the provided game level.
+func getHiderInterval(level int) time.Duration {
+	interval := hiderMaxInterval - time.Duration(level)*hiderSpeedUp
+
+	if interval < hiderMinInterval {
+		return hiderMinInterval
+	}
+
+	return interval
+}
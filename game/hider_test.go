@@ -0,0 +1,21 @@
+package game
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestGetHiderInterval tests the functionality of getHiderInterval
+func TestGetHiderInterval(t *testing.T) {
+	Convey("TestGetHiderInterval: Given the game level", t, func() {
+		Convey("the moving target should get faster as the level increases", func() {
+			So(getHiderInterval(0), ShouldEqual, hiderMaxInterval)
+			So(getHiderInterval(10), ShouldBeLessThan, getHiderInterval(1))
+		})
+
+		Convey("the moving target should never be faster than the minimum interval", func() {
+			So(getHiderInterval(1000), ShouldEqual, hiderMinInterval)
+		})
+	})
+}
@@ -0,0 +1,289 @@
+package maze
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	// DepthFirstGenerator creates perfect mazes using the depth-first search algorithm,
+	// also known as the recursive backtracker.
+	DepthFirstGenerator = "dfs"
+
+	// BraidedGenerator creates mazes using the depth-first search algorithm and then removes
+	// half of their dead ends, creating loops and multiple routes.
+	BraidedGenerator = "braided"
+)
+
+// generators defines the generator settings of the maze generation algorithms that can be
+// analyzed besides the difficulty presets.
+var generators = map[string]Difficulty{
+	DepthFirstGenerator: {Name: DepthFirstGenerator},
+	BraidedGenerator:    {Name: BraidedGenerator, Braid: 0.5},
+}
+
+// Metrics defines the measurements of a generated maze used to compare the maze generators.
+// DeadEndRatio is the fraction of the cells that have a single path. RiverFactor is the average
+// number of cells in the corridors leading to the dead ends, long winding corridors give a high
+// river factor while many short side branches give a low one. SolutionLength is the number of
+// moves on the shortest path to the target and Turns is the number of times that path changes
+// direction. BranchingFactor is the average number of paths other than the way forward and back
+// found at every cell along the shortest path. GenerationTime is the time taken to generate the maze.
+type Metrics struct {
+	DeadEndRatio    float64
+	RiverFactor     float64
+	SolutionLength  float64
+	BranchingFactor float64
+	Turns           float64
+	GenerationTime  time.Duration
+}
+
+// AnalyzeOptions defines the mazes generated by the analysis. If neither generators nor
+// presets are provided, all of them are analyzed.
+type AnalyzeOptions struct {
+	// Generators holds the names of the maze generation algorithms compared.
+	Generators []string
+
+	// Presets holds the names of the difficulty presets whose generator settings are compared.
+	Presets []string
+
+	// Sizes holds the maze sizes written as LENGTHxWIDTH, e.g. 20x10.
+	Sizes []string
+
+	// Count is the number of mazes generated for every generator and size, their metrics are averaged.
+	Count int
+
+	// Topology is the name of the shape of the cells, square cells are used if it is empty.
+	Topology string
+}
+
+// getGenerator returns the generator settings of the maze generation algorithm with the
+// provided name. If an invalid name is used an error is thrown.
+func getGenerator(name string) (Difficulty, error) {
+	if generator, ok := generators[name]; ok {
+		return generator, nil
+	}
+
+	return Difficulty{}, fmt.Errorf("Invalid generator found: %s. Allowed %s and %s",
+		name, DepthFirstGenerator, BraidedGenerator)
+}
+
+// getSize returns the maze dimensions written as LENGTHxWIDTH in the provided text.
+// If the text is not a valid size an error is thrown.
+func getSize(text string) (*Dimensions, error) {
+	parts := strings.Split(strings.ToLower(text), "x")
+
+	if len(parts) == 2 {
+		length, lengthErr := strconv.Atoi(parts[0])
+		width, widthErr := strconv.Atoi(parts[1])
+
+		if lengthErr == nil && widthErr == nil && length > 1 && width > 1 {
+			return &Dimensions{Length: length, Width: width}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Invalid maze size found: %s. Allowed LENGTHxWIDTH e.g. 20x10", text)
+}
+
+// Analyze generates the provided number of mazes for every maze generation algorithm, difficulty
+// preset and maze size provided and writes a table of their average metrics to the provided
+// writer. If an invalid generator, preset, size or topology is used an error is thrown.
+func Analyze(opts AnalyzeOptions, w io.Writer) error {
+	var settings []Difficulty
+
+	if opts.Topology == "" {
+		opts.Topology = SquareTopology
+	}
+
+	grid, err := getTopology(opts.Topology)
+	if err != nil {
+		return err
+	}
+
+	if opts.Count < 1 {
+		return fmt.Errorf("Invalid maze count found: %d. Allowed 1 and above", opts.Count)
+	}
+
+	if len(opts.Generators) == 0 && len(opts.Presets) == 0 {
+		opts.Generators = []string{DepthFirstGenerator, BraidedGenerator}
+
+		for name := range presets {
+			opts.Presets = append(opts.Presets, name)
+		}
+		sort.Slice(opts.Presets, func(i, j int) bool {
+			return presets[opts.Presets[i]].Seed < presets[opts.Presets[j]].Seed
+		})
+	}
+
+	for _, name := range opts.Generators {
+		generator, err := getGenerator(name)
+		if err != nil {
+			return err
+		}
+
+		settings = append(settings, generator)
+	}
+
+	for _, name := range opts.Presets {
+		preset, err := getDifficulty(name)
+		if err != nil {
+			return err
+		}
+
+		settings = append(settings, preset)
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "GENERATOR\tSIZE\tDEAD ENDS\tRIVER\tSOLUTION\tBRANCHING\tTURNS\tTIME\t")
+
+	for _, generator := range settings {
+		for _, text := range opts.Sizes {
+			size, err := getSize(text)
+			if err != nil {
+				return err
+			}
+
+			size.Topology = grid
+
+			metrics, err := size.getAverageMetrics(generator, opts.Count)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(table, "%s\t%dx%d\t%.1f%%\t%.2f\t%.1f\t%.2f\t%.1f\t%s\t\n", generator.Name,
+				size.Length, size.Width, metrics.DeadEndRatio*100, metrics.RiverFactor, metrics.SolutionLength,
+				metrics.BranchingFactor, metrics.Turns, metrics.GenerationTime.Round(time.Microsecond))
+		}
+	}
+
+	return table.Flush()
+}
+
+// getAverageMetrics generates the provided number of mazes using the provided generator settings
+// and returns the average of their metrics.
+func (config *Dimensions) getAverageMetrics(preset Difficulty, count int) (Metrics, error) {
+	var total Metrics
+
+	for i := 0; i < count; i++ {
+		started := time.Now()
+
+		data, err := config.generateMaze(1, preset)
+		if err != nil {
+			return total, err
+		}
+
+		metrics := config.getMetrics(data)
+
+		total.GenerationTime += time.Since(started)
+		total.DeadEndRatio += metrics.DeadEndRatio
+		total.RiverFactor += metrics.RiverFactor
+		total.SolutionLength += metrics.SolutionLength
+		total.BranchingFactor += metrics.BranchingFactor
+		total.Turns += metrics.Turns
+	}
+
+	return Metrics{
+		DeadEndRatio:    total.DeadEndRatio / float64(count),
+		RiverFactor:     total.RiverFactor / float64(count),
+		SolutionLength:  total.SolutionLength / float64(count),
+		BranchingFactor: total.BranchingFactor / float64(count),
+		Turns:           total.Turns / float64(count),
+		GenerationTime:  total.GenerationTime / time.Duration(count),
+	}, nil
+}
+
+// getMetrics returns the metrics of the provided maze, the generation time is not measured.
+func (config *Dimensions) getMetrics(maze [][]string) Metrics {
+	var (
+		metrics          Metrics
+		deadEnds, cells  int
+		corridors, turns int
+
+		path = config.solveMaze(maze, config.getCellNumber(config.StartPosition),
+			config.getCellNumber(config.FinalPosition))
+	)
+
+	for cell := 1; cell <= config.getCellsCount(); cell++ {
+		if config.masked[cell] {
+			continue
+		}
+
+		cells++
+
+		if len(config.getOpenNeighbors(maze, cell)) == 1 {
+			deadEnds++
+			corridors += config.getCorridorLength(maze, cell)
+		}
+	}
+
+	for step := 1; step < len(path); step++ {
+		metrics.BranchingFactor += float64(len(config.getOpenNeighbors(maze, path[step-1])))
+
+		if step > 1 && config.getDirection(path[step-2], path[step-1]) != config.getDirection(path[step-1], path[step]) {
+			turns++
+		}
+	}
+
+	if len(path) > 1 {
+		// Every cell on the path except the first one has a way back and a way forward.
+		metrics.BranchingFactor = (metrics.BranchingFactor - float64(2*len(path)-3)) / float64(len(path)-1)
+		metrics.SolutionLength = float64(len(path) - 1)
+	}
+
+	if deadEnds > 0 {
+		metrics.RiverFactor = float64(corridors) / float64(deadEnds)
+	}
+
+	if cells > 0 {
+		metrics.DeadEndRatio = float64(deadEnds) / float64(cells)
+	}
+
+	metrics.Turns = float64(turns)
+
+	return metrics
+}
+
+// getCorridorLength returns the number of cells in the corridor that starts at the provided
+// dead end and ends before the first cell with more than two paths or at another dead end.
+func (config *Dimensions) getCorridorLength(maze [][]string, deadEnd int) int {
+	previous, current, length := 0, deadEnd, 0
+
+	for {
+		openCells := config.getOpenNeighbors(maze, current)
+
+		if length > 0 && len(openCells) != 2 {
+			if len(openCells) == 1 {
+				length++
+			}
+			return length
+		}
+
+		length++
+
+		next := openCells[0]
+		if next == previous {
+			next = openCells[1]
+		}
+
+		previous, current = current, next
+	}
+}
+
+// getDirection returns the direction in which the provided neighbor is found from the provided cell.
+// An empty direction is returned if the cells are not neighbors.
+func (config *Dimensions) getDirection(cellNo, neighbor int) string {
+	neighbors := config.getCellNeighbors(cellNo)
+
+	for _, direction := range append(neighborDirections, floorDirections...) {
+		if getNeighbor(neighbors, direction) == neighbor {
+			return direction
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,141 @@
+package maze
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// analyzedMaze returns a 3 by 3 maze whose shortest path to the target runs along the top
+// and the right edges while a side branch winds through the remaining cells.
+func analyzedMaze() (*Dimensions, [][]string) {
+	val := &Dimensions{Length: 3, Width: 3}
+	data, _ := val.createPlayingField(1)
+
+	for _, path := range [][2]int{{1, 2}, {2, 3}, {3, 6}, {6, 9}, {2, 5}, {5, 4}, {4, 7}, {7, 8}} {
+		val.createPath(data, path[0], path[1])
+	}
+
+	val.StartPosition = val.getCellAddress(1).MiddleCenter
+	val.FinalPosition = val.getCellAddress(9).MiddleCenter
+
+	return val, data
+}
+
+// TestGetSize tests the functionality of getSize
+func TestGetSize(t *testing.T) {
+	Convey("TestGetSize: Given a maze size", t, func() {
+		Convey("that is valid, the dimensions should be returned", func() {
+			size, err := getSize("20X10")
+
+			So(err, ShouldBeNil)
+			So(size, ShouldResemble, &Dimensions{Length: 20, Width: 10})
+		})
+
+		Convey("that is invalid, an error should be returned", func() {
+			for _, text := range []string{"20", "20x", "1x10", "axb"} {
+				_, err := getSize(text)
+
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "Invalid maze size found: "+text)
+			}
+		})
+	})
+}
+
+// TestGetMetrics tests the functionality of getMetrics
+func TestGetMetrics(t *testing.T) {
+	Convey("TestGetMetrics: Given a maze with a side branch", t, func() {
+		val, data := analyzedMaze()
+		metrics := val.getMetrics(data)
+
+		Convey("the dead ends and the corridors leading to them should be measured", func() {
+			So(metrics.DeadEndRatio, ShouldAlmostEqual, 3.0/9)
+			So(metrics.RiverFactor, ShouldAlmostEqual, 8.0/3)
+		})
+
+		Convey("the shortest path to the target should be measured", func() {
+			So(metrics.SolutionLength, ShouldEqual, 4)
+			So(metrics.BranchingFactor, ShouldAlmostEqual, 0.25)
+			So(metrics.Turns, ShouldEqual, 1)
+		})
+	})
+}
+
+// TestGetCorridorLength tests the functionality of getCorridorLength
+func TestGetCorridorLength(t *testing.T) {
+	Convey("TestGetCorridorLength: Given a dead end", t, func() {
+		val, data := analyzedMaze()
+
+		Convey("the cells up to the first junction should be counted", func() {
+			So(val.getCorridorLength(data, 1), ShouldEqual, 1)
+			So(val.getCorridorLength(data, 9), ShouldEqual, 3)
+			So(val.getCorridorLength(data, 8), ShouldEqual, 4)
+		})
+
+		Convey("that leads to another dead end, all the cells should be counted", func() {
+			val = &Dimensions{Length: 3, Width: 1}
+			data, _ = val.createPlayingField(1)
+			val.createPath(data, 1, 2)
+			val.createPath(data, 2, 3)
+
+			So(val.getCorridorLength(data, 1), ShouldEqual, 3)
+		})
+	})
+}
+
+// TestGetDirection tests the functionality of getDirection
+func TestGetDirection(t *testing.T) {
+	Convey("TestGetDirection: Given a cell and another cell", t, func() {
+		val := &Dimensions{Length: 3, Width: 3, Depth: 2}
+
+		Convey("that is its neighbor, the direction of the neighbor should be returned", func() {
+			So(val.getDirection(5, 6), ShouldEqual, "RIGHT")
+			So(val.getDirection(5, 2), ShouldEqual, "UP")
+			So(val.getDirection(5, 14), ShouldEqual, "ABOVE")
+		})
+
+		Convey("that is not its neighbor, an empty direction should be returned", func() {
+			So(val.getDirection(5, 9), ShouldBeEmpty)
+		})
+	})
+}
+
+// TestAnalyze tests the functionality of Analyze
+func TestAnalyze(t *testing.T) {
+	Convey("TestAnalyze: Given the analysis options", t, func() {
+		var output bytes.Buffer
+
+		Convey("that are valid, a row of metrics should be written for every preset and size", func() {
+			err := Analyze(AnalyzeOptions{Presets: []string{"easy", "hard"}, Sizes: []string{"8x6"}, Count: 2}, &output)
+
+			So(err, ShouldBeNil)
+			So(output.String(), ShouldContainSubstring, "DEAD ENDS")
+			So(strings.Fields(output.String()), ShouldContain, "easy")
+			So(strings.Fields(output.String()), ShouldContain, "hard")
+			So(strings.Count(output.String(), "8x6"), ShouldEqual, 2)
+		})
+
+		Convey("that are valid, a row of metrics should be written for every generator and size", func() {
+			err := Analyze(AnalyzeOptions{Generators: []string{DepthFirstGenerator, BraidedGenerator},
+				Sizes: []string{"8x6", "10x10"}, Count: 2}, &output)
+
+			So(err, ShouldBeNil)
+			So(strings.Fields(output.String()), ShouldContain, DepthFirstGenerator)
+			So(strings.Fields(output.String()), ShouldContain, BraidedGenerator)
+			So(strings.Fields(output.String()), ShouldNotContain, "easy")
+			So(strings.Count(output.String(), "10x10"), ShouldEqual, 2)
+		})
+
+		Convey("that have an invalid generator, preset, size or count, an error should be returned", func() {
+			So(Analyze(AnalyzeOptions{Generators: []string{"kruskal"}, Sizes: []string{"8x6"}, Count: 1}, &output),
+				ShouldNotBeNil)
+			So(Analyze(AnalyzeOptions{Presets: []string{"impossible"}, Sizes: []string{"8x6"}, Count: 1}, &output),
+				ShouldNotBeNil)
+			So(Analyze(AnalyzeOptions{Sizes: []string{"8"}, Count: 1}, &output), ShouldNotBeNil)
+			So(Analyze(AnalyzeOptions{Sizes: []string{"8x6"}}, &output), ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,580 @@
+package maze
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Kind defines what a part of the maze represents.
+type Kind int
+
+const (
+	// Plain parts are the walls, the paths and the cells without any item.
+	Plain Kind = iota
+
+	// Coin cells add to the scores once collected.
+	Coin
+
+	// TimeBonus cells extend the time left once collected.
+	TimeBonus
+
+	// Key cells open all the doors of their color once collected.
+	Key
+
+	// Door walls can only be crossed after the key of their color is collected.
+	Door
+
+	// Gate walls can only be crossed in a single direction.
+	Gate
+
+	// Teleporter cells move the player to the other teleporter with the same digit.
+	Teleporter
+
+	// Stairs cells lead to the floor above, the floor below or both.
+	Stairs
+
+	// Crossing cells hold a bridge with a tunnel passing under it.
+	Crossing
+)
+
+// Config defines the settings used to create a maze. Empty values use square cells, the normal
+// preset, the thinnest walls and the farthest target.
+type Config struct {
+	// Length and Width define the number of cells along the horizontal and the vertical edges.
+	Length int
+	Width  int
+
+	// Floors defines the number of floors joined by stairs, zero or one means a single floor.
+	Floors int
+
+	// Topology defines the name of the shape of the cells.
+	Topology string
+
+	// Wrap joins the opposite maze edges.
+	Wrap bool
+
+	// Shape defines the name of a built-in maze shape or the path to a mask file.
+	Shape string
+
+	// Difficulty defines the name of the preset whose generator settings are used.
+	Difficulty string
+
+	// Intensity defines how thick the walls are drawn, from 1 to 3.
+	Intensity int
+
+	// Weave adds crossings where corridors pass over and under each other.
+	Weave bool
+
+	// Target defines the target placement strategy.
+	Target string
+
+	// MinDistance defines the shortest distance to the target, only used by the
+	// DistanceTarget strategy.
+	MinDistance int
+
+	// Teleporters places paired teleporters.
+	Teleporters bool
+
+	// OneWayGates places one-way gates.
+	OneWayGates bool
+
+	// Items places coins, time bonuses, keys and doors.
+	Items bool
+}
+
+// Maze defines a generated maze together with the positions of the player, the target and the
+// computer seekers. The cells are numbered from one, from left to right and then from top to
+// bottom, starting from the lowest floor. The player starts on the starting point of the maze.
+type Maze struct {
+	config  *Dimensions
+	grid    [][]string
+	opts    Config
+	preset  Difficulty
+	seekers []*seeker
+
+	// pending is set while the teleporters, the one-way gates and the items wait for the
+	// target to be chosen.
+	pending bool
+}
+
+// Element defines a part of a maze floor as it is drawn on the terminal. Row is the row of the
+// floor holding the part, Column is the number of parts before it on the row and X is the number
+// of characters before it. Key is the key of the Key and Door parts.
+type Element struct {
+	Row    int
+	Column int
+	X      int
+	Text   string
+	Kind   Kind
+	Key    string
+}
+
+// Theme defines how a maze is rendered as text. Player, Target and Path are drawn on the
+// cells of the player, the target and the shortest path between them respectively.
+// The shortest path is not drawn if Path is empty.
+type Theme struct {
+	Player string
+	Target string
+	Path   string
+}
+
+var (
+	// PlainTheme renders the maze with the player and the target.
+	PlainTheme = Theme{Player: "@", Target: "#"}
+
+	// SolutionTheme renders the maze with the player, the target and the shortest path between them.
+	SolutionTheme = Theme{Player: "@", Target: "#", Path: "."}
+)
+
+// NewMaze generates a maze using the provided settings. With the ChosenTarget strategy the
+// teleporters, the one-way gates and the items are only placed once the target is set using
+// SetTarget. If the settings are invalid or the shape leaves too few cells an error is thrown.
+func NewMaze(opts Config) (*Maze, error) {
+	var err error
+
+	m := &Maze{opts: opts, pending: opts.Target == ChosenTarget}
+
+	if m.opts.Difficulty == "" {
+		m.opts.Difficulty = "normal"
+	}
+
+	if m.opts.Topology == "" {
+		m.opts.Topology = SquareTopology
+	}
+
+	if m.opts.Target == "" {
+		m.opts.Target = FarthestTarget
+	}
+
+	if m.opts.Intensity == 0 {
+		m.opts.Intensity = 1
+	}
+
+	if m.preset, err = getDifficulty(m.opts.Difficulty); err != nil {
+		return nil, err
+	}
+
+	grid, err := getTopology(m.opts.Topology)
+
+	switch {
+	case err != nil:
+		return nil, err
+
+	case opts.Length < 2 || opts.Width < 2:
+		return nil, fmt.Errorf("Invalid maze size found: %dx%d. Allowed 2x2 and above", opts.Length, opts.Width)
+
+	case opts.Weave && grid != SquareTopology:
+		return nil, fmt.Errorf("Weave mazes are only supported by the %s topology", SquareTopology)
+
+	case opts.Wrap && grid != SquareTopology:
+		return nil, fmt.Errorf("Wrap-around mazes are only supported by the %s topology", SquareTopology)
+
+	case opts.Wrap && (opts.Length < 3 || opts.Width < 3):
+		return nil, fmt.Errorf("Invalid wrap-around maze size found: %dx%d. Allowed 3x3 and above", opts.Length, opts.Width)
+	}
+
+	m.config = &Dimensions{Length: opts.Length, Width: opts.Width, Depth: opts.Floors, Topology: grid, Wrap: opts.Wrap}
+
+	if opts.Shape != "" {
+		shape, err := getShape(opts.Shape)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = m.config.applyShape(shape); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.grid, err = m.config.generateMaze(m.opts.Intensity, m.preset); err != nil {
+		return nil, err
+	}
+
+	if opts.Weave {
+		m.config.weaveMaze(m.grid, weaveDensity)
+	}
+
+	if err = m.config.placeTarget(m.grid, m.opts.Target, opts.MinDistance); err != nil {
+		return nil, err
+	}
+
+	if !m.pending {
+		m.placeExtras()
+	}
+
+	return m, nil
+}
+
+// placeExtras places the teleporters, the one-way gates and the items requested in the maze.
+func (m *Maze) placeExtras() {
+	if m.opts.Teleporters || m.opts.OneWayGates {
+		area, teleporters, gates := m.config.getCellsCount(), 0, 0
+
+		if m.opts.Teleporters {
+			teleporters = 1 + area/200
+		}
+
+		if m.opts.OneWayGates {
+			gates = 1 + area/50
+		}
+
+		m.config.placePassages(m.grid, teleporters, gates)
+		m.config.setTarget(m.grid, m.Target())
+	}
+
+	if m.opts.Items {
+		m.config.placeItems(m.grid)
+	}
+
+	m.pending = false
+}
+
+// Cells returns the numbers of all the cells found inside the maze on every floor.
+func (m *Maze) Cells() []int {
+	var cells []int
+
+	for cell := 1; cell <= m.config.getCellsCount(); cell++ {
+		if !m.config.masked[cell] {
+			cells = append(cells, cell)
+		}
+	}
+
+	return cells
+}
+
+// isCell checks if the provided cell is found inside the maze.
+func (m *Maze) isCell(cell int) bool {
+	return cell > 0 && cell <= m.config.getCellsCount() && !m.config.masked[cell]
+}
+
+// Floors returns the number of floors in the maze.
+func (m *Maze) Floors() int {
+	return m.config.getFloors()
+}
+
+// Walls returns the directions of the sides of the provided cell that are closed by a wall,
+// in clockwise order. Doors and one-way gates are walls too. Nil is returned for cells that
+// are not found inside the maze.
+func (m *Maze) Walls(cell int) []string {
+	var walls []string
+
+	if !m.isCell(cell) {
+		return nil
+	}
+
+	addr := m.config.getCellAddress(cell)
+
+	for _, direction := range m.config.getDirections(cell) {
+		if wall := getWall(addr, direction); !isSpaceFound(m.grid[wall[0]][wall[1]]) {
+			walls = append(walls, direction)
+		}
+	}
+
+	return walls
+}
+
+// Neighbors returns the cells that can be reached from the provided cell in a single move.
+// Moving into a teleporter leads to its pair while stairs lead to the floor above or below.
+func (m *Maze) Neighbors(cell int) []int {
+	if !m.isCell(cell) {
+		return nil
+	}
+	return m.config.getOpenNeighbors(m.grid, cell)
+}
+
+// Player returns the cell of the player.
+func (m *Maze) Player() int {
+	return m.config.getCellNumber(m.config.StartPosition)
+}
+
+// Target returns the cell of the target.
+func (m *Maze) Target() int {
+	return m.config.getCellNumber(m.config.FinalPosition)
+}
+
+// PathLength returns the number of moves on the shortest path from the starting point
+// to the target, including the detours made to collect the keys of any doors on the way.
+func (m *Maze) PathLength() int {
+	return m.config.PathLength
+}
+
+// Difficulty returns the difficulty preset used to generate the maze.
+func (m *Maze) Difficulty() Difficulty {
+	return m.preset
+}
+
+// TimeBudget returns the number of seconds allowed to locate the target.
+func (m *Maze) TimeBudget() int {
+	return m.preset.getTimeBudget(m.config.PathLength)
+}
+
+// Solve returns the shortest path from the player to the target including both cells.
+// An empty path is returned if the target is locked behind a door.
+func (m *Maze) Solve() []int {
+	return m.config.solveMaze(m.grid, m.Player(), m.Target())
+}
+
+// PathTo returns the shortest path from the player to the provided cell including both cells.
+// An empty path is returned if the cell cannot be reached or is locked behind a door.
+func (m *Maze) PathTo(cell int) []int {
+	if !m.isCell(cell) {
+		return nil
+	}
+	return m.config.solveMaze(m.grid, m.Player(), cell)
+}
+
+// Hint returns the shortest path from the player to the target. If the target is locked
+// behind a door, the shortest path to the nearest key is returned instead.
+func (m *Maze) Hint() []int {
+	if path := m.Solve(); len(path) > 0 {
+		return path
+	}
+
+	return m.config.solveMaze(m.grid, m.Player(), m.config.getNearestKey(m.grid, m.Player()))
+}
+
+// Move moves the player in the provided direction if a path is found in that direction and
+// collects the item found on the new cell. The kind of the item collected is returned, Plain
+// is returned if no item is collected.
+func (m *Maze) Move(direction string) Kind {
+	m.config.playerMovement(m.grid, direction)
+	return m.config.collectItem(m.grid)
+}
+
+// Step returns the cell reached by moving from the provided cell in the provided direction
+// without moving the player. The same cell is returned if no path is found in that direction.
+func (m *Maze) Step(cell int, direction string) int {
+	if !m.isCell(cell) {
+		return cell
+	}
+
+	walker := *m.config
+	walker.StartPosition = m.config.getCellAddress(cell).MiddleCenter
+	walker.playerMovement(m.grid, direction)
+
+	return walker.getCellNumber(walker.StartPosition)
+}
+
+// Direction returns the direction that leads from the provided cell to the provided neighbor
+// in a single move. An empty direction is returned if the neighbor cannot be reached in a
+// single move.
+func (m *Maze) Direction(cell, neighbor int) string {
+	for _, direction := range append(append([]string{}, neighborDirections...), floorDirections...) {
+		if m.Step(cell, direction) == neighbor && neighbor != cell {
+			return direction
+		}
+	}
+
+	return ""
+}
+
+// SetTarget places the target on the provided cell. With the ChosenTarget strategy the
+// teleporters, the one-way gates and the items are placed once the target is set. If the
+// cell is not found inside the maze or is the cell of the player an error is thrown.
+func (m *Maze) SetTarget(cell int) error {
+	if !m.isCell(cell) || cell == m.Player() {
+		return fmt.Errorf("Invalid target cell found: %d. Allowed any maze cell other than the player's", cell)
+	}
+
+	m.config.setTarget(m.grid, cell)
+
+	if m.pending {
+		m.placeExtras()
+	}
+
+	return nil
+}
+
+// MoveTarget moves the target a single step away from the player without crossing doors.
+func (m *Maze) MoveTarget() {
+	m.config.moveTarget(m.grid)
+}
+
+// AddSeeker adds a computer seeker using the provided strategy at the starting point.
+// Seekers treat doors as walls, so they never locate a target locked behind a door.
+// If an invalid strategy is used an error is thrown.
+func (m *Maze) AddSeeker(strategy string) error {
+	opponent, err := newSeeker(strategy, m.config.getCellNumber(m.config.StartPosition))
+	if err != nil {
+		return err
+	}
+
+	m.seekers = append(m.seekers, opponent)
+
+	return nil
+}
+
+// MoveSeekers makes a single move for every computer seeker. True is returned if any of
+// the seekers has located the target.
+func (m *Maze) MoveSeekers() bool {
+	for _, opponent := range m.seekers {
+		opponent.move(m.config, m.grid)
+
+		if opponent.position == m.Target() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Seekers returns the cells of the computer seekers.
+func (m *Maze) Seekers() []int {
+	cells := make([]int, len(m.seekers))
+
+	for index, opponent := range m.seekers {
+		cells[index] = opponent.position
+	}
+
+	return cells
+}
+
+// WrapSize returns the number of rows and columns after which the elements of every floor
+// repeat when the maze wraps around. Both are zero if the maze does not wrap around.
+func (m *Maze) WrapSize() (rows, columns int) {
+	if !m.config.Wrap {
+		return 0, 0
+	}
+	return 2 * m.config.Width, 2 * m.config.Length
+}
+
+// Locate returns the floor of the provided cell, starting from zero, together with the row
+// and the column of its content among the elements of that floor. All of them are -1 if
+// the cell is not found inside the maze.
+func (m *Maze) Locate(cell int) (floor, row, column int) {
+	if !m.isCell(cell) {
+		return -1, -1, -1
+	}
+
+	center := m.config.getCellAddress(cell).MiddleCenter
+	floor = m.config.getFloor(center)
+	point, _ := m.config.getFloorPoint(center, floor)
+
+	return floor, point[0], point[1]
+}
+
+// CellAt returns the cell whose content is found on the provided row and column of the
+// provided floor, as returned by Locate. Zero is returned if no cell is found there.
+func (m *Maze) CellAt(floor, row, column int) int {
+	for _, cell := range m.Cells() {
+		if cellFloor, cellRow, cellColumn := m.Locate(cell); cellFloor == floor && cellRow == row && cellColumn == column {
+			return cell
+		}
+	}
+
+	return 0
+}
+
+// getKind returns the kind of the provided part of the maze together with its key if it
+// is a key or a door.
+func getKind(text string) (Kind, string) {
+	switch {
+	case text == coinItem:
+		return Coin, ""
+
+	case text == timeItem:
+		return TimeBonus, ""
+
+	case getCellKey(text) != "":
+		return Key, getCellKey(text)
+
+	case getDoorKey(text) != "":
+		return Door, getDoorKey(text)
+
+	case oneWayGates[text] != "":
+		return Gate, ""
+
+	case isTeleporter(text):
+		return Teleporter, ""
+
+	case text == stairsUp, text == stairsDown, text == stairsBoth:
+		return Stairs, ""
+
+	case crossingGlyphs[text] != "":
+		return Crossing, ""
+	}
+
+	return Plain, ""
+}
+
+// Elements returns the parts of the provided floor as they are drawn on the terminal,
+// starting from zero. Nil is returned if the floor is not found in the maze.
+func (m *Maze) Elements(floor int) []Element {
+	var elements []Element
+
+	if floor < 0 || floor >= m.Floors() {
+		return nil
+	}
+
+	for row, line := range m.config.getFloorView(m.grid, floor) {
+		x := 0
+
+		for column, text := range line {
+			if text == "\n" {
+				continue
+			}
+
+			kind, key := getKind(text)
+			if kind == Crossing {
+				text = crossingGlyphs[text]
+			}
+
+			elements = append(elements, Element{Row: row, Column: column, X: x, Text: text, Kind: kind, Key: key})
+			x += utf8.RuneCountInString(text)
+		}
+	}
+
+	return elements
+}
+
+// Render writes the maze as text using the provided theme. The floors are written from the
+// lowest to the highest, separated by blank lines.
+func (m *Maze) Render(w io.Writer, theme Theme) error {
+	var (
+		text    strings.Builder
+		markers = map[int]string{}
+	)
+
+	if theme.Path != "" {
+		for _, cell := range m.Solve() {
+			markers[cell] = theme.Path
+		}
+	}
+
+	markers[m.Target()], markers[m.Player()] = theme.Target, theme.Player
+
+	for floor := 0; floor < m.Floors(); floor++ {
+		if floor > 0 {
+			text.WriteString("\n")
+		}
+
+		row, content := 0, map[[2]int]string{}
+
+		for cell, marker := range markers {
+			if cellFloor, cellRow, column := m.Locate(cell); cellFloor == floor && marker != "" {
+				content[[2]int{cellRow, column}] = " " + marker + " "
+			}
+		}
+
+		for _, element := range m.Elements(floor) {
+			if element.Row != row {
+				text.WriteString("\n")
+				row = element.Row
+			}
+
+			if marker, ok := content[[2]int{element.Row, element.Column}]; ok {
+				element.Text = marker
+			}
+
+			text.WriteString(element.Text)
+		}
+
+		text.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, text.String())
+
+	return err
+}
@@ -0,0 +1,337 @@
+package maze
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// apiMaze returns the test maze with the player on cell 1 and the target on cell 9.
+func apiMaze() *Maze {
+	val := &Dimensions{Length: 3, Width: 3}
+	val.StartPosition = val.getCellAddress(1).MiddleCenter
+	val.FinalPosition = val.getCellAddress(9).MiddleCenter
+
+	return &Maze{config: val, grid: copyMaze(testMaze), preset: presets["normal"]}
+}
+
+// countKinds returns the number of the parts of every kind found on all the maze floors.
+func countKinds(m *Maze) map[Kind]int {
+	kinds := map[Kind]int{}
+
+	for floor := 0; floor < m.Floors(); floor++ {
+		for _, element := range m.Elements(floor) {
+			kinds[element.Kind]++
+		}
+	}
+
+	return kinds
+}
+
+// TestNewMaze tests the functionality of NewMaze
+func TestNewMaze(t *testing.T) {
+	Convey("TestNewMaze: Given the maze settings", t, func() {
+		Convey("that are valid, a maze with a reachable target should be generated", func() {
+			m, err := NewMaze(Config{Length: 10, Width: 6})
+
+			So(err, ShouldBeNil)
+			So(m.Cells(), ShouldHaveLength, 60)
+			So(m.Floors(), ShouldEqual, 1)
+			So(m.Player(), ShouldNotEqual, m.Target())
+			So(m.Solve(), ShouldHaveLength, m.PathLength()+1)
+			So(m.Difficulty().Name, ShouldEqual, "normal")
+		})
+
+		Convey("that use the chosen target, the items should only be placed once the target is set", func() {
+			m, err := NewMaze(Config{Length: 10, Width: 8, Target: ChosenTarget, Items: true})
+
+			So(err, ShouldBeNil)
+			So(countKinds(m)[TimeBonus], ShouldEqual, 0)
+
+			So(m.SetTarget(m.Target()), ShouldBeNil)
+			So(countKinds(m)[TimeBonus], ShouldBeGreaterThan, 0)
+		})
+
+		Convey("that are used by several goroutines at once, every maze should be generated", func() {
+			var (
+				wg     sync.WaitGroup
+				mazes  = make([]*Maze, 8)
+				errors = make([]error, len(mazes))
+			)
+
+			for i := range mazes {
+				wg.Add(1)
+
+				go func(i int) {
+					defer wg.Done()
+					mazes[i], errors[i] = NewMaze(Config{Length: 20, Width: 10})
+				}(i)
+			}
+
+			wg.Wait()
+
+			for i, m := range mazes {
+				So(errors[i], ShouldBeNil)
+				So(m.Cells(), ShouldHaveLength, 200)
+				So(m.Solve(), ShouldHaveLength, m.PathLength()+1)
+			}
+		})
+
+		Convey("that are invalid, an error should be returned", func() {
+			for _, opts := range []Config{
+				{Length: 1, Width: 6},
+				{Length: 10, Width: 6, Difficulty: "impossible"},
+				{Length: 10, Width: 6, Topology: "octagon"},
+				{Length: 10, Width: 6, Topology: HexTopology, Weave: true},
+				{Length: 10, Width: 6, Topology: TriangleTopology, Wrap: true},
+				{Length: 2, Width: 6, Wrap: true},
+				{Length: 10, Width: 2, Wrap: true},
+				{Length: 10, Width: 6, Target: "nearest"},
+				{Length: 10, Width: 6, Shape: "missing.mask"},
+			} {
+				_, err := NewMaze(opts)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
+
+// TestWalls tests the functionality of Walls
+func TestWalls(t *testing.T) {
+	Convey("TestWalls: Given a cell", t, func() {
+		m := apiMaze()
+
+		Convey("found inside the maze, the directions of its closed sides should be returned", func() {
+			So(m.Walls(1), ShouldResemble, []string{"UP", "DOWN", "LEFT"})
+			So(m.Walls(5), ShouldResemble, []string{"DOWN"})
+		})
+
+		Convey("of triangular cells, only the three sides of the cell should be checked", func() {
+			triangles, err := NewMaze(Config{Length: 4, Width: 3, Topology: TriangleTopology})
+
+			So(err, ShouldBeNil)
+			So(triangles.Walls(1), ShouldNotContain, "UP")
+			So(triangles.Walls(2), ShouldNotContain, "DOWN")
+		})
+
+		Convey("not found inside the maze, nil should be returned", func() {
+			So(m.Walls(0), ShouldBeNil)
+			So(m.Walls(10), ShouldBeNil)
+		})
+	})
+}
+
+// TestNeighbors tests the functionality of Neighbors
+func TestNeighbors(t *testing.T) {
+	Convey("TestNeighbors: Given a cell, the cells reachable in a single move should be returned", t, func() {
+		m := apiMaze()
+
+		So(m.Neighbors(5), ShouldResemble, []int{4, 6, 2})
+		So(m.Neighbors(7), ShouldResemble, []int{4})
+		So(m.Neighbors(10), ShouldBeNil)
+	})
+}
+
+// TestSolve tests the functionality of Solve and Hint
+func TestSolve(t *testing.T) {
+	Convey("TestSolve: Given the player and the target positions", t, func() {
+		m := apiMaze()
+
+		Convey("the shortest path between them should be returned", func() {
+			So(m.Solve(), ShouldResemble, []int{1, 2, 5, 6, 9})
+			So(m.Hint(), ShouldResemble, m.Solve())
+		})
+
+		Convey("where the target is locked behind a door, the hint should lead to the key", func() {
+			m.config.setWall(m.grid, 6, 9, "A")
+			m.config.setCell(m.grid, 7, " a ")
+
+			So(m.Solve(), ShouldBeEmpty)
+			So(m.Hint(), ShouldResemble, []int{1, 2, 5, 4, 7})
+		})
+	})
+}
+
+// TestPathTo tests the functionality of PathTo and Direction
+func TestPathTo(t *testing.T) {
+	Convey("TestPathTo: Given a cell", t, func() {
+		m := apiMaze()
+
+		Convey("that can be reached, the shortest path from the player should be returned", func() {
+			So(m.PathTo(7), ShouldResemble, []int{1, 2, 5, 4, 7})
+			So(m.PathTo(0), ShouldBeNil)
+		})
+
+		Convey("next to another cell, the direction leading to it should be returned", func() {
+			So(m.Direction(1, 2), ShouldEqual, "RIGHT")
+			So(m.Direction(5, 2), ShouldEqual, "UP")
+			So(m.Direction(1, 4), ShouldBeEmpty)
+			So(m.Direction(1, 1), ShouldBeEmpty)
+		})
+	})
+}
+
+// TestMove tests the functionality of Move and Step
+func TestMove(t *testing.T) {
+	Convey("TestMove: Given the player position", t, func() {
+		m := apiMaze()
+		m.config.setCell(m.grid, 2, coinItem)
+
+		Convey("moving along a path should collect the item found on the new cell", func() {
+			So(m.Move("RIGHT"), ShouldEqual, Coin)
+			So(m.Player(), ShouldEqual, 2)
+
+			So(m.Move("LEFT"), ShouldEqual, Plain)
+			So(m.Player(), ShouldEqual, 1)
+		})
+
+		Convey("moving into a wall should keep the player in place", func() {
+			So(m.Move("DOWN"), ShouldEqual, Plain)
+			So(m.Player(), ShouldEqual, 1)
+		})
+
+		Convey("stepping from a cell should not move the player", func() {
+			So(m.Step(5, "LEFT"), ShouldEqual, 4)
+			So(m.Step(5, "DOWN"), ShouldEqual, 5)
+			So(m.Player(), ShouldEqual, 1)
+		})
+	})
+}
+
+// TestSetTarget tests the functionality of SetTarget
+func TestSetTarget(t *testing.T) {
+	Convey("TestSetTarget: Given a cell", t, func() {
+		m := apiMaze()
+
+		Convey("found inside the maze, the target should be placed on it", func() {
+			So(m.SetTarget(7), ShouldBeNil)
+			So(m.Target(), ShouldEqual, 7)
+			So(m.PathLength(), ShouldEqual, 4)
+		})
+
+		Convey("of the player or outside the maze, an error should be returned", func() {
+			for _, cell := range []int{0, 1, 10} {
+				err := m.SetTarget(cell)
+
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "Invalid target cell found")
+			}
+			So(m.Target(), ShouldEqual, 9)
+		})
+	})
+}
+
+// TestSeekers tests the functionality of AddSeeker, MoveSeekers and Seekers
+func TestSeekers(t *testing.T) {
+	Convey("TestSeekers: Given a seeker strategy", t, func() {
+		m := apiMaze()
+
+		Convey("that is valid, the seeker should race to the target", func() {
+			So(m.AddSeeker(PartialBFS), ShouldBeNil)
+			So(m.Seekers(), ShouldResemble, []int{1})
+
+			found := false
+			for moves := 0; moves < 20 && !found; moves++ {
+				found = m.MoveSeekers()
+			}
+
+			So(found, ShouldBeTrue)
+			So(m.Seekers(), ShouldResemble, []int{9})
+		})
+
+		Convey("that is invalid, an error should be returned", func() {
+			So(m.AddSeeker("teleport"), ShouldNotBeNil)
+			So(m.Seekers(), ShouldBeEmpty)
+		})
+	})
+}
+
+// TestWrapSize tests the functionality of WrapSize
+func TestWrapSize(t *testing.T) {
+	Convey("TestWrapSize: Given a maze", t, func() {
+		Convey("that wraps around, the rows and the columns after which it repeats should be returned", func() {
+			m, err := NewMaze(Config{Length: 10, Width: 6, Wrap: true})
+			So(err, ShouldBeNil)
+
+			rows, columns := m.WrapSize()
+			So([]int{rows, columns}, ShouldResemble, []int{12, 20})
+		})
+
+		Convey("that does not wrap around, zero should be returned", func() {
+			rows, columns := apiMaze().WrapSize()
+			So([]int{rows, columns}, ShouldResemble, []int{0, 0})
+		})
+	})
+}
+
+// TestElements tests the functionality of Elements, Locate and CellAt
+func TestElements(t *testing.T) {
+	Convey("TestElements: Given a maze floor", t, func() {
+		m := apiMaze()
+		m.config.setCell(m.grid, 5, coinItem)
+		m.config.setWall(m.grid, 6, 9, "A")
+
+		Convey("that is found in the maze, its parts and their positions should be returned", func() {
+			elements := m.Elements(0)
+
+			So(elements, ShouldHaveLength, 49)
+			So(elements[8], ShouldResemble, Element{Row: 1, Column: 1, X: 1, Text: "   "})
+			So(elements[24], ShouldResemble, Element{Row: 3, Column: 3, X: 5, Text: coinItem, Kind: Coin})
+			So(elements[33], ShouldResemble, Element{Row: 4, Column: 5, X: 9, Text: "AAA", Kind: Door, Key: "a"})
+		})
+
+		Convey("that is not found in the maze, nil should be returned", func() {
+			So(m.Elements(1), ShouldBeNil)
+		})
+
+		Convey("the position of a cell content should be found", func() {
+			floor, row, column := m.Locate(5)
+			So([]int{floor, row, column}, ShouldResemble, []int{0, 3, 3})
+
+			floor, row, column = m.Locate(10)
+			So([]int{floor, row, column}, ShouldResemble, []int{-1, -1, -1})
+		})
+
+		Convey("the cell found on a position of the floor should be returned", func() {
+			So(m.CellAt(0, 3, 3), ShouldEqual, 5)
+			So(m.CellAt(0, 5, 1), ShouldEqual, 7)
+			So(m.CellAt(0, 0, 0), ShouldEqual, 0)
+			So(m.CellAt(1, 3, 3), ShouldEqual, 0)
+		})
+	})
+}
+
+// TestRender tests the functionality of Render
+func TestRender(t *testing.T) {
+	Convey("TestRender: Given a theme", t, func() {
+		var output bytes.Buffer
+
+		m := apiMaze()
+
+		Convey("that draws the shortest path, it should be written between the player and the target", func() {
+			So(m.Render(&output, SolutionTheme), ShouldBeNil)
+			So(strings.Split(output.String(), "\n"), ShouldResemble, []string{
+				"|---|---|---|",
+				"| @   . |   |",
+				"|---|   |   |",
+				"|     .   . |",
+				"|   |---|   |",
+				"|   |     # |",
+				"|---|---|---|",
+				"",
+			})
+		})
+
+		Convey("of a maze with several floors, the floors should be separated by blank lines", func() {
+			floors, err := NewMaze(Config{Length: 4, Width: 3, Floors: 2})
+
+			So(err, ShouldBeNil)
+			So(floors.Render(&output, Theme{}), ShouldBeNil)
+			So(strings.Split(output.String(), "\n"), ShouldHaveLength, 16)
+			So(strings.Split(output.String(), "\n")[7], ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,238 @@
+package maze
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"strings"
+)
+
+const (
+	// pageWidth and pageHeight define the size of an A4 booklet page in points.
+	pageWidth, pageHeight = 595.0, 842.0
+
+	// pageMargin defines the blank space left around the edges of a booklet page in points.
+	pageMargin = 48.0
+
+	// headingSize defines the font size of the page headings in points.
+	headingSize = 18.0
+
+	// bezierCircle defines the distance of the control points from the ends of the four
+	// curves drawing a circle, as a fraction of its radius.
+	bezierCircle = 0.5523
+)
+
+// BookletOptions defines the printable booklet of mazes. Levels is the number of mazes, one
+// per page, starting from the FirstLevel whose size follows the difficulty preset. Title is
+// written on top of every page. Difficulty, Topology, Shape and Intensity work as they do in
+// Config while empty values use the normal preset and square cells.
+type BookletOptions struct {
+	Title      string
+	Difficulty string
+	FirstLevel int
+	Levels     int
+	Topology   string
+	Shape      string
+	Intensity  int
+}
+
+// Booklet writes a PDF booklet with a maze on every page followed by the answer key, where
+// the same mazes are drawn with their solutions. The mazes grow from one level to the next.
+// If the options are invalid an error is thrown.
+func Booklet(w io.Writer, opts BookletOptions) error {
+	var pages, answers []string
+
+	if opts.Levels < 1 {
+		return fmt.Errorf("Invalid number of levels found: %d. Allowed 1 and above", opts.Levels)
+	}
+
+	if opts.FirstLevel < 0 || opts.FirstLevel+opts.Levels-1 >= maxLevel {
+		return fmt.Errorf("Invalid levels found: %d to %d. Allowed 0 to %d",
+			opts.FirstLevel, opts.FirstLevel+opts.Levels-1, maxLevel-1)
+	}
+
+	if opts.Difficulty == "" {
+		opts.Difficulty = "normal"
+	}
+
+	preset, err := getDifficulty(opts.Difficulty)
+	if err != nil {
+		return err
+	}
+
+	// Mazes follow the aspect ratio of the page area left below the heading.
+	aspect := (pageWidth - 2*pageMargin) / (pageHeight - 2*pageMargin - 2*headingSize)
+
+	for level := opts.FirstLevel; level < opts.FirstLevel+opts.Levels; level++ {
+		size := getIdealDimensions(generateMazeArea(level, preset), aspect)
+
+		m, err := NewMaze(Config{
+			Length:     size.Length,
+			Width:      size.Width,
+			Topology:   opts.Topology,
+			Shape:      opts.Shape,
+			Difficulty: opts.Difficulty,
+			Intensity:  opts.Intensity,
+		})
+		if err != nil {
+			return err
+		}
+
+		heading := fmt.Sprintf("Level %d", level)
+		if opts.Title != "" {
+			heading = opts.Title + " - " + heading
+		}
+
+		pages = append(pages, m.getPageContent(heading, false))
+		answers = append(answers, m.getPageContent("Answers - "+heading, true))
+	}
+
+	return writePDF(w, append(pages, answers...))
+}
+
+// getPageContent returns the PDF drawing instructions of a booklet page with the provided
+// heading on top of the maze. The maze is scaled to fill the page below the heading.
+func (m *Maze) getPageContent(heading string, solution bool) string {
+	var (
+		content strings.Builder
+
+		width, height = m.getFloorSize()
+		floors        = float64(m.Floors())
+
+		// The drawing adds a margin of half a cell on every side of the maze.
+		size = math.Min((pageWidth-2*pageMargin)/(width+1),
+			(pageHeight-2*pageMargin-2*headingSize)/(height*floors+floors))
+
+		picture = m.getDrawing(size, solution, true)
+
+		// PDF pages measure heights from the bottom, the drawing is centered below the heading.
+		left = (pageWidth - float64(picture.width)) / 2
+		top  = pageHeight - pageMargin - 2*headingSize
+
+		x = func(p point) float64 { return left + p.x }
+		y = func(p point) float64 { return top - p.y }
+	)
+
+	fmt.Fprintf(&content, "BT /F1 %.0f Tf %.2f %.2f Td (%s) Tj ET\n",
+		headingSize, pageMargin, pageHeight-pageMargin-headingSize, getPDFText(heading))
+
+	fmt.Fprintf(&content, "%s RG %.2f w 1 J 1 j\n", getPDFColor(wallColor), picture.thickness)
+	for _, wall := range picture.walls {
+		fmt.Fprintf(&content, "%.2f %.2f m %.2f %.2f l S\n", x(wall.from), y(wall.from), x(wall.to), y(wall.to))
+	}
+
+	fmt.Fprintf(&content, "%s RG\n", getPDFColor(solutionColor))
+	for _, path := range picture.paths {
+		for index, p := range path {
+			operator := "l"
+			if index == 0 {
+				operator = "m"
+			}
+			fmt.Fprintf(&content, "%.2f %.2f %s ", x(p), y(p), operator)
+		}
+		content.WriteString("S\n")
+	}
+
+	for _, circle := range picture.markers {
+		var (
+			cx, cy = x(circle.center), y(circle.center)
+			r, k   = circle.radius, circle.radius * bezierCircle
+		)
+
+		fmt.Fprintf(&content, "%s rg %.2f %.2f m ", strings.ToLower(getPDFColor(circle.color)), cx+r, cy)
+		fmt.Fprintf(&content, "%.2f %.2f %.2f %.2f %.2f %.2f c ", cx+r, cy+k, cx+k, cy+r, cx, cy+r)
+		fmt.Fprintf(&content, "%.2f %.2f %.2f %.2f %.2f %.2f c ", cx-k, cy+r, cx-r, cy+k, cx-r, cy)
+		fmt.Fprintf(&content, "%.2f %.2f %.2f %.2f %.2f %.2f c ", cx-r, cy-k, cx-k, cy-r, cx, cy-r)
+		fmt.Fprintf(&content, "%.2f %.2f %.2f %.2f %.2f %.2f c f\n", cx+k, cy-r, cx+r, cy-k, cx+r, cy)
+	}
+
+	return content.String()
+}
+
+// getPDFColor returns the provided color as the red, green and blue values used by the PDF
+// stroke color operator. Its lowercase form sets the fill color instead.
+func getPDFColor(c color.RGBA) string {
+	return fmt.Sprintf("%.3f %.3f %.3f", float64(c.R)/255, float64(c.G)/255, float64(c.B)/255)
+}
+
+// getPDFText returns the provided text escaped for use inside a PDF string. Characters outside
+// the standard font are replaced by question marks.
+func getPDFText(text string) string {
+	var escaped strings.Builder
+
+	for _, char := range text {
+		switch {
+		case char == '\\', char == '(', char == ')':
+			escaped.WriteRune('\\')
+			escaped.WriteRune(char)
+
+		case char < ' ' || char > '~':
+			escaped.WriteRune('?')
+
+		default:
+			escaped.WriteRune(char)
+		}
+	}
+
+	return escaped.String()
+}
+
+// writePDF writes a PDF document with a page for every one of the provided page contents.
+// The contents are compressed and the headings use the standard Helvetica font, so nothing
+// needs to be embedded.
+func writePDF(w io.Writer, pages []string) error {
+	var (
+		document bytes.Buffer
+		offsets  []int
+		kids     []string
+
+		addObject = func(object string) {
+			offsets = append(offsets, document.Len())
+			fmt.Fprintf(&document, "%d 0 obj\n%s\nendobj\n", len(offsets), object)
+		}
+	)
+
+	// The catalog, the page tree and the font come first, every page is then followed by its content.
+	for index := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 4+2*index))
+	}
+
+	document.WriteString("%PDF-1.4\n")
+
+	addObject("<< /Type /Catalog /Pages 2 0 R >>")
+	addObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	addObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>")
+
+	for index, content := range pages {
+		var stream bytes.Buffer
+
+		writer := zlib.NewWriter(&stream)
+		if _, err := writer.Write([]byte(content)); err != nil {
+			return err
+		}
+
+		if err := writer.Close(); err != nil {
+			return err
+		}
+
+		addObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", pageWidth, pageHeight, 5+2*index))
+		addObject(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", stream.Len(), stream.String()))
+	}
+
+	xref := document.Len()
+
+	fmt.Fprintf(&document, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&document, "%010d 00000 n \n", offset)
+	}
+
+	fmt.Fprintf(&document, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := document.WriteTo(w)
+
+	return err
+}
@@ -0,0 +1,86 @@
+package maze
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestBooklet tests the functionality of Booklet
+func TestBooklet(t *testing.T) {
+	Convey("TestBooklet: Given the booklet options", t, func() {
+		var output bytes.Buffer
+
+		Convey("that are valid, a page for every maze and its answer should be written", func() {
+			err := Booklet(&output, BookletOptions{Title: "Class (A)", FirstLevel: 2, Levels: 3, Topology: HexTopology})
+
+			So(err, ShouldBeNil)
+			So(output.String(), ShouldStartWith, "%PDF-1.4\n")
+			So(output.String(), ShouldEndWith, "%%EOF\n")
+			So(output.String(), ShouldContainSubstring, "/Count 6")
+			So(strings.Count(output.String(), "/Type /Page "), ShouldEqual, 6)
+		})
+
+		Convey("that are invalid, an error should be returned", func() {
+			for _, opts := range []BookletOptions{
+				{Levels: 0},
+				{FirstLevel: -1, Levels: 2},
+				{FirstLevel: maxLevel - 1, Levels: 2},
+				{Levels: 2, Difficulty: "impossible"},
+				{Levels: 2, Topology: "octagon"},
+			} {
+				So(Booklet(&output, opts), ShouldNotBeNil)
+			}
+		})
+	})
+}
+
+// TestWritePDF tests the functionality of writePDF
+func TestWritePDF(t *testing.T) {
+	Convey("TestWritePDF: Given the page contents, the cross reference table should point at every object", t, func() {
+		var output bytes.Buffer
+
+		So(writePDF(&output, []string{"0 0 m 10 10 l S", "BT /F1 18 Tf (Hi) Tj ET"}), ShouldBeNil)
+
+		document := output.String()
+		start, err := strconv.Atoi(regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(document)[1])
+
+		So(err, ShouldBeNil)
+		So(document[start:], ShouldStartWith, "xref\n0 8\n")
+
+		for object, offset := range regexp.MustCompile(`(\d{10}) 00000 n`).FindAllStringSubmatch(document, -1) {
+			position, _ := strconv.Atoi(offset[1])
+			So(document[position:], ShouldStartWith, strconv.Itoa(object+1)+" 0 obj\n")
+		}
+	})
+}
+
+// TestGetPDFText tests the functionality of getPDFText
+func TestGetPDFText(t *testing.T) {
+	Convey("TestGetPDFText: Given a text, the PDF string characters should be escaped", t, func() {
+		So(getPDFText("Level 1"), ShouldEqual, "Level 1")
+		So(getPDFText(`(a\b)`), ShouldEqual, `\(a\\b\)`)
+		So(getPDFText("Café"), ShouldEqual, "Caf?")
+	})
+}
+
+// TestGetPageContent tests the functionality of getPageContent
+func TestGetPageContent(t *testing.T) {
+	Convey("TestGetPageContent: Given a maze, its walls should be drawn below the heading", t, func() {
+		m := apiMaze()
+
+		puzzle := m.getPageContent("Level 1", false)
+		answer := m.getPageContent("Answers", true)
+
+		So(puzzle, ShouldStartWith, "BT /F1 18 Tf 48.00 776.00 Td (Level 1) Tj ET\n")
+		So(strings.Count(puzzle, " l S\n"), ShouldEqual, 16)
+		So(strings.Count(puzzle, " c f\n"), ShouldEqual, 2)
+
+		// The solution is a single line through the five cells on the shortest path.
+		So(strings.Count(answer, " l S\n"), ShouldEqual, 17)
+	})
+}
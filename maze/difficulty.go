@@ -0,0 +1,65 @@
+package maze
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Difficulty defines a game preset that controls how hard a given tapoo level is.
+// TimeFactor is the number of seconds awarded for every cell on the shortest path
+// between the player and the target. Branching is the probability (0 - 1) that the maze
+// generator resumes carving from a random earlier cell instead of the newest one, producing
+// more side branches. Braid is the fraction (0 - 1) of the dead ends that are removed after
+// the maze is generated, creating loops and multiple routes. Visibility is the number of cells
+// around the player that are shown, zero means the whole maze is visible. Hints is the number
+// of hints allowed per level.
+// Seed and Diff control the maze size growth between the game levels.
+type Difficulty struct {
+	Name       string
+	TimeFactor float64
+	Branching  float64
+	Braid      float64
+	Visibility int
+	Hints      int
+	Seed       int
+	Diff       int
+}
+
+// presets defines the difficulty levels supported by the game.
+var presets = map[string]Difficulty{
+	"easy": {
+		Name: "easy", TimeFactor: 3, Branching: 0.1, Braid: 0.5,
+		Visibility: 0, Hints: 5, Seed: 64, Diff: 6,
+	},
+	"normal": {
+		Name: "normal", TimeFactor: 2, Branching: 0.25, Braid: 0.25,
+		Visibility: 0, Hints: 3, Seed: seed, Diff: diff,
+	},
+	"hard": {
+		Name: "hard", TimeFactor: 1.5, Branching: 0.5, Braid: 0.1,
+		Visibility: 6, Hints: 1, Seed: 150, Diff: 15,
+	},
+	"insane": {
+		Name: "insane", TimeFactor: 1, Branching: 0.75, Braid: 0,
+		Visibility: 3, Hints: 0, Seed: 200, Diff: 20,
+	},
+}
+
+// getDifficulty returns the difficulty preset associated with the provided name.
+// If an invalid name is used an error is thrown.
+func getDifficulty(name string) (Difficulty, error) {
+	preset, ok := presets[strings.ToLower(name)]
+	if ok {
+		return preset, nil
+	}
+
+	return preset, fmt.Errorf(
+		"Invalid difficulty found: %s. Allowed easy, normal, hard and insane", name)
+}
+
+// getTimeBudget calculates the number of seconds allowed to locate the target
+// from the number of cells found on the shortest path to the target.
+func (d Difficulty) getTimeBudget(pathLength int) int {
+	return int(math.Ceil(d.TimeFactor * float64(pathLength)))
+}
@@ -0,0 +1,56 @@
+package maze
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestGetDifficulty tests the functionality of getDifficulty
+func TestGetDifficulty(t *testing.T) {
+	Convey("TestGetDifficulty: Given a difficulty name ", t, func() {
+		Convey("that is not supported, the second value returned should implement an error", func() {
+			for _, name := range []string{"", "medium", "expert"} {
+				preset, err := getDifficulty(name)
+
+				So(err, ShouldImplement, (*error)(nil))
+				So(err.Error(), ShouldContainSubstring, "Invalid difficulty found:")
+				So(preset, ShouldResemble, Difficulty{})
+			}
+		})
+
+		Convey("that is supported, the matching preset should be returned regardless of the case used", func() {
+			for _, name := range []string{"easy", "Normal", "HARD", "insane"} {
+				preset, err := getDifficulty(name)
+
+				So(err, ShouldBeNil)
+				So(preset.TimeFactor, ShouldBeGreaterThan, 0)
+				So(preset.Seed, ShouldBeGreaterThan, 0)
+				So(preset.Diff, ShouldBeGreaterThan, 0)
+			}
+		})
+
+		Convey("that is harder, less time and hints should be allowed", func() {
+			order := []string{"easy", "normal", "hard", "insane"}
+
+			for i := 1; i < len(order); i++ {
+				easier, harder := presets[order[i-1]], presets[order[i]]
+
+				So(harder.TimeFactor, ShouldBeLessThan, easier.TimeFactor)
+				So(harder.Hints, ShouldBeLessThan, easier.Hints)
+				So(harder.Seed, ShouldBeGreaterThan, easier.Seed)
+			}
+		})
+	})
+}
+
+// TestGetTimeBudget tests the functionality of getTimeBudget
+func TestGetTimeBudget(t *testing.T) {
+	Convey("TestGetTimeBudget: Given the length of the shortest path to the target", t, func() {
+		Convey("the time budget should be the ceiled product of the length and the time factor", func() {
+			So(presets["easy"].getTimeBudget(10), ShouldEqual, 30)
+			So(presets["hard"].getTimeBudget(11), ShouldEqual, 17)
+			So(presets["insane"].getTimeBudget(0), ShouldEqual, 0)
+		})
+	})
+}
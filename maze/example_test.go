@@ -0,0 +1,61 @@
+package maze_test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dmigwi/tapoo/maze"
+)
+
+func ExampleNewMaze() {
+	m, err := maze.NewMaze(maze.Config{Length: 10, Width: 6, Difficulty: "hard"})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(len(m.Cells()), m.Floors(), m.Difficulty().Name)
+	// Output: 60 1 hard
+}
+
+func ExampleMaze_Solve() {
+	m, err := maze.NewMaze(maze.Config{Length: 10, Width: 6})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	path := m.Solve()
+
+	fmt.Println(path[0] == m.Player(), path[len(path)-1] == m.Target(), len(path)-1 == m.PathLength())
+	// Output: true true true
+}
+
+func ExampleMaze_Neighbors() {
+	m, err := maze.NewMaze(maze.Config{Length: 10, Width: 6})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Every side of a square cell is either a wall or a path to a neighbor.
+	sides := true
+	for _, cell := range m.Cells() {
+		sides = sides && len(m.Walls(cell))+len(m.Neighbors(cell)) == 4
+	}
+
+	fmt.Println(sides)
+	// Output: true
+}
+
+func ExampleMaze_Render() {
+	m, err := maze.NewMaze(maze.Config{Length: 10, Width: 6})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if err := m.Render(os.Stdout, maze.SolutionTheme); err != nil {
+		fmt.Println(err)
+	}
+}
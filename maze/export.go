@@ -0,0 +1,392 @@
+package maze
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"sort"
+)
+
+const (
+	// SVGFormat exports the maze as a scalable vector image.
+	SVGFormat = "svg"
+
+	// PNGFormat exports the maze as a raster image.
+	PNGFormat = "png"
+)
+
+var (
+	// wallColor defines the color of the maze walls.
+	wallColor = color.RGBA{0x21, 0x21, 0x21, 0xff}
+
+	// solutionColor defines the color of the shortest path drawn from the start to the target.
+	solutionColor = color.RGBA{0x1e, 0x88, 0xe5, 0xff}
+
+	// startColor defines the color of the starting point marker.
+	startColor = color.RGBA{0x43, 0xa0, 0x47, 0xff}
+
+	// targetColor defines the color of the target marker.
+	targetColor = color.RGBA{0xe5, 0x39, 0x35, 0xff}
+
+	// stairsColor defines the color of the markers of the cells holding stairs.
+	stairsColor = color.RGBA{0x9e, 0x9e, 0x9e, 0xff}
+)
+
+// ExportOptions defines how a maze is drawn as an image. Format is either SVGFormat or
+// PNGFormat and CellSize is the width of a square cell in pixels, 32 pixels are used if it
+// is zero. Solution draws the shortest path from the start to the target while Markers draws
+// the starting point, the target and the stairs. The walls get thicker as the maze intensity
+// increases.
+type ExportOptions struct {
+	Format   string
+	CellSize int
+	Solution bool
+	Markers  bool
+}
+
+// point defines a position on the image in pixels.
+type point struct {
+	x, y float64
+}
+
+// segment defines a straight wall between two points.
+type segment struct {
+	from, to point
+}
+
+// marker defines a filled circle drawn on a cell.
+type marker struct {
+	center point
+	radius float64
+	color  color.RGBA
+}
+
+// drawing defines the shapes making up a maze image. Paths are the lines drawn through the
+// cell centers, a path is split wherever it jumps between cells that do not touch.
+type drawing struct {
+	width, height int
+	thickness     float64
+	walls         []segment
+	paths         [][]point
+	markers       []marker
+}
+
+// Export writes the maze as an image using the provided options. The floors are drawn from
+// the lowest at the top to the highest at the bottom. If an invalid format or cell size is
+// used an error is thrown.
+func (m *Maze) Export(w io.Writer, opts ExportOptions) error {
+	if opts.CellSize == 0 {
+		opts.CellSize = 32
+	}
+
+	if opts.CellSize < 4 {
+		return fmt.Errorf("Invalid cell size found: %d. Allowed 4 pixels and above", opts.CellSize)
+	}
+
+	picture := m.getDrawing(float64(opts.CellSize), opts.Solution, opts.Markers)
+
+	switch opts.Format {
+	case SVGFormat:
+		return picture.writeSVG(w)
+
+	case PNGFormat:
+		return picture.writePNG(w)
+	}
+
+	return fmt.Errorf("Invalid export format found: %s. Allowed %s and %s", opts.Format, SVGFormat, PNGFormat)
+}
+
+// getFloorSize returns the width and the height of a single floor measured in square cells.
+func (m *Maze) getFloorSize() (width, height float64) {
+	length, rows := float64(m.config.Length), float64(m.config.Width)
+
+	switch m.config.getTopology().(type) {
+	case triangleGrid:
+		return (length + 1) / 2, rows * math.Sqrt(3) / 2
+
+	case hexGrid:
+		return (length*1.5 + 0.5) / math.Sqrt(3), rows + 0.5
+	}
+
+	return length, rows
+}
+
+// getCellSides returns the center of the provided cell and the ends of every one of its sides
+// measured in square cells from the top left corner of the maze, including the floors above it.
+func (m *Maze) getCellSides(cell int) (point, map[string]segment) {
+	var (
+		area        = m.config.Length * m.config.Width
+		index       = (cell - 1) % area
+		row, column = float64(index / m.config.Length), float64(index % m.config.Length)
+
+		_, floorHeight = m.getFloorSize()
+
+		// Every floor is drawn one cell below the floor before it.
+		top = float64((cell-1)/area) * (floorHeight + 1)
+	)
+
+	switch grid := m.config.getTopology().(type) {
+	case triangleGrid:
+		var (
+			height = math.Sqrt(3) / 2
+			left   = column / 2
+			upper  = top + row*height
+			lower  = upper + height
+		)
+
+		if grid.isPointingUp(m.config, index+1) {
+			apex, bottomLeft, bottomRight := point{left + 0.5, upper}, point{left, lower}, point{left + 1, lower}
+
+			return point{left + 0.5, upper + height*2/3}, map[string]segment{
+				"RIGHT": {apex, bottomRight},
+				"DOWN":  {bottomLeft, bottomRight},
+				"LEFT":  {bottomLeft, apex},
+			}
+		}
+
+		topLeft, topRight, apex := point{left, upper}, point{left + 1, upper}, point{left + 0.5, lower}
+
+		return point{left + 0.5, upper + height/3}, map[string]segment{
+			"UP":    {topLeft, topRight},
+			"RIGHT": {topRight, apex},
+			"LEFT":  {topLeft, apex},
+		}
+
+	case hexGrid:
+		var (
+			radius = 1 / math.Sqrt(3)
+			center = point{radius + column*1.5*radius, top + row + 0.5 + float64(index%m.config.Length%2)/2}
+			corner = func(angle float64) point {
+				return point{center.x + radius*math.Cos(angle*math.Pi/3), center.y + radius*math.Sin(angle*math.Pi/3)}
+			}
+		)
+
+		return center, map[string]segment{
+			"UP":        {corner(4), corner(5)},
+			"UPRIGHT":   {corner(5), corner(0)},
+			"DOWNRIGHT": {corner(0), corner(1)},
+			"DOWN":      {corner(1), corner(2)},
+			"DOWNLEFT":  {corner(2), corner(3)},
+			"UPLEFT":    {corner(3), corner(4)},
+		}
+	}
+
+	upper := top + row
+
+	return point{column + 0.5, upper + 0.5}, map[string]segment{
+		"UP":    {point{column, upper}, point{column + 1, upper}},
+		"RIGHT": {point{column + 1, upper}, point{column + 1, upper + 1}},
+		"DOWN":  {point{column, upper + 1}, point{column + 1, upper + 1}},
+		"LEFT":  {point{column, upper}, point{column, upper + 1}},
+	}
+}
+
+// getDrawing returns the shapes making up the maze image using the provided cell size in pixels.
+// The walls shared by two cells are only drawn once and the maze is surrounded by a margin of
+// half a cell.
+func (m *Maze) getDrawing(size float64, solution, markers bool) drawing {
+	var (
+		width, height = m.getFloorSize()
+		floors        = float64(m.Floors())
+		margin        = size / 2
+
+		scale = func(p point) point {
+			return point{margin + p.x*size, margin + p.y*size}
+		}
+
+		scalePath = func(path []point) []point {
+			for index := range path {
+				path[index] = scale(path[index])
+			}
+			return path
+		}
+
+		// Walls are compared by their ends rounded to a hundredth of a cell, in either order.
+		getKey = func(wall segment) [4]float64 {
+			key := [4]float64{math.Round(wall.from.x * 100), math.Round(wall.from.y * 100),
+				math.Round(wall.to.x * 100), math.Round(wall.to.y * 100)}
+
+			if key[0] > key[2] || (key[0] == key[2] && key[1] > key[3]) {
+				key = [4]float64{key[2], key[3], key[0], key[1]}
+			}
+			return key
+		}
+
+		walls = map[[4]float64]segment{}
+
+		picture = drawing{
+			width:     int(math.Ceil(width*size + 2*margin)),
+			height:    int(math.Ceil((height*floors+floors-1)*size + 2*margin)),
+			thickness: math.Max(1, size*float64(m.opts.Intensity)/16),
+		}
+	)
+
+	for _, cell := range m.Cells() {
+		_, sides := m.getCellSides(cell)
+
+		for _, direction := range m.Walls(cell) {
+			walls[getKey(sides[direction])] = sides[direction]
+		}
+	}
+
+	keys := make([][4]float64, 0, len(walls))
+	for key := range walls {
+		keys = append(keys, key)
+	}
+
+	// The walls are sorted to write the same image every time the same maze is exported.
+	sort.Slice(keys, func(i, j int) bool {
+		for k := range keys[i] {
+			if keys[i][k] != keys[j][k] {
+				return keys[i][k] < keys[j][k]
+			}
+		}
+		return false
+	})
+
+	for _, key := range keys {
+		picture.walls = append(picture.walls, segment{scale(walls[key].from), scale(walls[key].to)})
+	}
+
+	if solution {
+		var path []point
+
+		for _, cell := range m.Solve() {
+			center, _ := m.getCellSides(cell)
+
+			// Teleporters, stairs and wrapped edges move the path between cells that do not touch.
+			if len(path) > 0 && math.Hypot(center.x-path[len(path)-1].x, center.y-path[len(path)-1].y) > 1.5 {
+				picture.paths = append(picture.paths, scalePath(path))
+				path = nil
+			}
+
+			path = append(path, center)
+		}
+
+		if len(path) > 1 {
+			picture.paths = append(picture.paths, scalePath(path))
+		}
+	}
+
+	if markers {
+		for _, cell := range m.Cells() {
+			pos := m.config.getCellAddress(cell).MiddleCenter
+
+			if kind, _ := getKind(m.grid[pos[0]][pos[1]]); kind == Stairs {
+				center, _ := m.getCellSides(cell)
+				picture.markers = append(picture.markers, marker{scale(center), size / 8, stairsColor})
+			}
+		}
+
+		start, _ := m.getCellSides(m.Player())
+		target, _ := m.getCellSides(m.Target())
+
+		picture.markers = append(picture.markers, marker{scale(start), size / 4, startColor},
+			marker{scale(target), size / 4, targetColor})
+	}
+
+	return picture
+}
+
+// getHexColor returns the provided color written as #rrggbb.
+func getHexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// writeSVG writes the drawing as an SVG image.
+func (d drawing) writeSVG(w io.Writer) error {
+	buf := bufio.NewWriter(w)
+
+	fmt.Fprintf(buf, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n",
+		d.width, d.height, d.width, d.height)
+	fmt.Fprintf(buf, "<rect width=\"100%%\" height=\"100%%\" fill=\"#ffffff\"/>\n")
+
+	fmt.Fprintf(buf, "<g stroke=\"%s\" stroke-width=\"%.2f\" stroke-linecap=\"round\">\n",
+		getHexColor(wallColor), d.thickness)
+	for _, wall := range d.walls {
+		fmt.Fprintf(buf, "<line x1=\"%.2f\" y1=\"%.2f\" x2=\"%.2f\" y2=\"%.2f\"/>\n",
+			wall.from.x, wall.from.y, wall.to.x, wall.to.y)
+	}
+	fmt.Fprintln(buf, "</g>")
+
+	for _, path := range d.paths {
+		fmt.Fprintf(buf, "<polyline fill=\"none\" stroke=\"%s\" stroke-width=\"%.2f\" stroke-linecap=\"round\" stroke-linejoin=\"round\" points=\"",
+			getHexColor(solutionColor), d.thickness)
+		for index, p := range path {
+			if index > 0 {
+				fmt.Fprint(buf, " ")
+			}
+			fmt.Fprintf(buf, "%.2f,%.2f", p.x, p.y)
+		}
+		fmt.Fprintln(buf, "\"/>")
+	}
+
+	for _, circle := range d.markers {
+		fmt.Fprintf(buf, "<circle cx=\"%.2f\" cy=\"%.2f\" r=\"%.2f\" fill=\"%s\"/>\n",
+			circle.center.x, circle.center.y, circle.radius, getHexColor(circle.color))
+	}
+
+	fmt.Fprintln(buf, "</svg>")
+
+	return buf.Flush()
+}
+
+// writePNG writes the drawing as a PNG image.
+func (d drawing) writePNG(w io.Writer) error {
+	canvas := image.NewRGBA(image.Rect(0, 0, d.width, d.height))
+
+	for index := range canvas.Pix {
+		canvas.Pix[index] = 0xff
+	}
+
+	for _, wall := range d.walls {
+		drawLine(canvas, wall, d.thickness, wallColor)
+	}
+
+	for _, path := range d.paths {
+		for index := 1; index < len(path); index++ {
+			drawLine(canvas, segment{path[index-1], path[index]}, d.thickness, solutionColor)
+		}
+	}
+
+	for _, circle := range d.markers {
+		drawLine(canvas, segment{circle.center, circle.center}, 2*circle.radius, circle.color)
+	}
+
+	return png.Encode(w, canvas)
+}
+
+// drawLine paints every pixel whose center is closer to the provided segment than half of the
+// provided thickness, which gives the line rounded ends.
+func drawLine(canvas *image.RGBA, line segment, thickness float64, c color.RGBA) {
+	var (
+		half   = thickness / 2
+		dx, dy = line.to.x - line.from.x, line.to.y - line.from.y
+		length = dx*dx + dy*dy
+
+		bounds = image.Rect(
+			int(math.Floor(math.Min(line.from.x, line.to.x)-half)), int(math.Floor(math.Min(line.from.y, line.to.y)-half)),
+			int(math.Ceil(math.Max(line.from.x, line.to.x)+half))+1, int(math.Ceil(math.Max(line.from.y, line.to.y)+half))+1,
+		).Intersect(canvas.Bounds())
+	)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			px, py := float64(x)+0.5, float64(y)+0.5
+
+			// The position along the segment of the point closest to the pixel, from 0 to 1.
+			t := 0.0
+			if length > 0 {
+				t = math.Max(0, math.Min(1, ((px-line.from.x)*dx+(py-line.from.y)*dy)/length))
+			}
+
+			if math.Hypot(px-line.from.x-t*dx, py-line.from.y-t*dy) <= half {
+				canvas.SetRGBA(x, y, c)
+			}
+		}
+	}
+}
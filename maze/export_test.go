@@ -0,0 +1,70 @@
+package maze
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestExport tests the functionality of Export
+func TestExport(t *testing.T) {
+	Convey("TestExport: Given the export options", t, func() {
+		var output bytes.Buffer
+
+		m := apiMaze()
+
+		Convey("that use the svg format, every wall should be drawn once together with the solution and the markers", func() {
+			err := m.Export(&output, ExportOptions{Format: SVGFormat, Solution: true, Markers: true})
+
+			So(err, ShouldBeNil)
+			So(output.String(), ShouldStartWith, `<svg xmlns="http://www.w3.org/2000/svg" width="128" height="128"`)
+			So(strings.Count(output.String(), "<line "), ShouldEqual, 16)
+			So(output.String(), ShouldContainSubstring, `points="32.00,32.00 64.00,32.00 64.00,64.00 96.00,64.00 96.00,96.00"`)
+			So(strings.Count(output.String(), "<circle "), ShouldEqual, 2)
+		})
+
+		Convey("that use the png format, an image of the maze size should be written", func() {
+			So(m.Export(&output, ExportOptions{Format: PNGFormat, CellSize: 10}), ShouldBeNil)
+
+			picture, err := png.Decode(&output)
+
+			So(err, ShouldBeNil)
+			So(picture.Bounds().Dx(), ShouldEqual, 40)
+			So(picture.Bounds().Dy(), ShouldEqual, 40)
+
+			// The top left corner of the maze is a wall while the center of the first cell is a path.
+			So(picture.At(5, 5), ShouldResemble, wallColor)
+			So(picture.At(10, 10), ShouldNotResemble, wallColor)
+		})
+
+		Convey("that are invalid, an error should be returned", func() {
+			So(m.Export(&output, ExportOptions{Format: "gif"}), ShouldNotBeNil)
+			So(m.Export(&output, ExportOptions{Format: SVGFormat, CellSize: 2}), ShouldNotBeNil)
+		})
+	})
+}
+
+// TestGetDrawing tests the functionality of getDrawing
+func TestGetDrawing(t *testing.T) {
+	Convey("TestGetDrawing: Given a maze topology, the walls drawn should fit inside the image", t, func() {
+		for _, topology := range []string{SquareTopology, HexTopology, TriangleTopology} {
+			m, err := NewMaze(Config{Length: 6, Width: 4, Floors: 2, Topology: topology, Intensity: 2})
+			So(err, ShouldBeNil)
+
+			picture := m.getDrawing(20, true, true)
+
+			So(picture.thickness, ShouldEqual, 2.5)
+			So(picture.walls, ShouldNotBeEmpty)
+
+			for _, wall := range picture.walls {
+				for _, end := range []point{wall.from, wall.to} {
+					So(end.x, ShouldBeBetweenOrEqual, 10, float64(picture.width)-10)
+					So(end.y, ShouldBeBetweenOrEqual, 10, float64(picture.height)-10)
+				}
+			}
+		}
+	})
+}
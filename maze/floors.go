@@ -0,0 +1,83 @@
+package maze
+
+const (
+	// stairsUp defines the content of a cell with stairs leading to the floor above.
+	stairsUp = " ↑ "
+
+	// stairsDown defines the content of a cell with stairs leading to the floor below.
+	stairsDown = " ↓ "
+
+	// stairsBoth defines the content of a cell with stairs leading to both the floor
+	// above and the floor below.
+	stairsBoth = " ↕ "
+)
+
+// floorDirections defines the directions used to move between the floors of the maze.
+var floorDirections = []string{"ABOVE", "BELOW"}
+
+// hasStairs checks if the provided cell content has stairs leading in the provided direction.
+func hasStairs(cell, direction string) bool {
+	switch direction {
+	case "ABOVE":
+		return cell == stairsUp || cell == stairsBoth
+
+	case "BELOW":
+		return cell == stairsDown || cell == stairsBoth
+	}
+
+	return false
+}
+
+// addStairs adds stairs leading in the provided direction to the provided cell.
+func (config *Dimensions) addStairs(maze [][]string, cellNo int, direction string) {
+	switch content := config.getCellContent(maze, cellNo); {
+	case hasStairs(content, direction):
+
+	case content == stairsUp, content == stairsDown:
+		config.setCell(maze, cellNo, stairsBoth)
+
+	case direction == "ABOVE":
+		config.setCell(maze, cellNo, stairsUp)
+
+	case direction == "BELOW":
+		config.setCell(maze, cellNo, stairsDown)
+	}
+}
+
+// getFloors returns the number of floors in the maze. Zero depth means a single floor.
+func (config *Dimensions) getFloors() int {
+	if config.Depth > 1 {
+		return config.Depth
+	}
+	return 1
+}
+
+// getCellsCount returns the number of cells found on all the floors of the maze.
+func (config *Dimensions) getCellsCount() int {
+	return config.Length * config.Width * config.getFloors()
+}
+
+// getFloorRows returns the number of rows used to draw a single floor of the maze.
+func (config *Dimensions) getFloorRows() int {
+	return config.getTopology().getRows(config)
+}
+
+// getFloor returns the floor where the provided point is found, starting from zero.
+func (config *Dimensions) getFloor(point []int) int {
+	return point[0] / config.getFloorRows()
+}
+
+// getFloorView returns the rows of the maze that make up the provided floor.
+func (config *Dimensions) getFloorView(maze [][]string, floor int) [][]string {
+	rows := config.getFloorRows()
+	return maze[floor*rows : (floor+1)*rows]
+}
+
+// getFloorPoint converts the provided point into its position on the view of the provided
+// floor. False is returned if the point is not found on that floor.
+func (config *Dimensions) getFloorPoint(point []int, floor int) ([]int, bool) {
+	if config.getFloor(point) != floor {
+		return nil, false
+	}
+	return []int{point[0] - floor*config.getFloorRows(), point[1]}, true
+}
@@ -0,0 +1,104 @@
+package maze
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestHasStairs tests the functionality of hasStairs
+func TestHasStairs(t *testing.T) {
+	Convey("TestHasStairs: Given the cell content and a direction", t, func() {
+		Convey("stairs should only lead in their own directions", func() {
+			So(hasStairs(stairsUp, "ABOVE"), ShouldBeTrue)
+			So(hasStairs(stairsUp, "BELOW"), ShouldBeFalse)
+			So(hasStairs(stairsDown, "BELOW"), ShouldBeTrue)
+			So(hasStairs(stairsDown, "ABOVE"), ShouldBeFalse)
+			So(hasStairs(stairsBoth, "ABOVE"), ShouldBeTrue)
+			So(hasStairs(stairsBoth, "BELOW"), ShouldBeTrue)
+		})
+
+		Convey("that is not stairs or not a floor direction, false should be returned", func() {
+			So(hasStairs(emptyCell, "ABOVE"), ShouldBeFalse)
+			So(hasStairs(stairsBoth, "UP"), ShouldBeFalse)
+		})
+	})
+}
+
+// TestAddStairs tests the functionality of addStairs
+func TestAddStairs(t *testing.T) {
+	Convey("TestAddStairs: Given a cell in a maze with three floors", t, func() {
+		val := &Dimensions{Length: 2, Width: 2, Depth: 3}
+		data, err := val.createPlayingField(1)
+
+		So(err, ShouldBeNil)
+
+		Convey("stairs in both directions should be combined", func() {
+			val.addStairs(data, 5, "ABOVE")
+			So(val.getCellContent(data, 5), ShouldEqual, stairsUp)
+
+			val.addStairs(data, 5, "ABOVE")
+			So(val.getCellContent(data, 5), ShouldEqual, stairsUp)
+
+			val.addStairs(data, 5, "BELOW")
+			So(val.getCellContent(data, 5), ShouldEqual, stairsBoth)
+		})
+
+		Convey("creating a path to the floor above should add stairs on both cells", func() {
+			val.createPath(data, 2, 6)
+			So(val.getCellContent(data, 2), ShouldEqual, stairsUp)
+			So(val.getCellContent(data, 6), ShouldEqual, stairsDown)
+			So(val.getOpenNeighbors(data, 2), ShouldResemble, []int{6})
+			So(val.getOpenNeighbors(data, 6), ShouldResemble, []int{2})
+		})
+	})
+}
+
+// TestGetFloorPoint tests the functionality of getFloorPoint
+func TestGetFloorPoint(t *testing.T) {
+	Convey("TestGetFloorPoint: Given a point in a maze with three floors of 6 by 5 cells", t, func() {
+		val := &Dimensions{Length: 6, Width: 5, Depth: 3}
+
+		Convey("the point on its own floor view should be returned", func() {
+			point, ok := val.getFloorPoint([]int{14, 3}, 1)
+			So(ok, ShouldBeTrue)
+			So(point, ShouldResemble, []int{3, 3})
+			So(val.getFloor([]int{14, 3}), ShouldEqual, 1)
+		})
+
+		Convey("false should be returned for the other floors", func() {
+			_, ok := val.getFloorPoint([]int{14, 3}, 0)
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
+
+// TestGenerateMazeFloors tests the functionality of generateMaze in multi-floor mazes
+func TestGenerateMazeFloors(t *testing.T) {
+	Convey("TestGenerateMazeFloors: Given the dimensions of a maze with three floors", t, func() {
+		val := &Dimensions{Length: 8, Width: 6, Depth: 3}
+		data, err := val.generateMaze(1, Difficulty{})
+
+		So(err, ShouldBeNil)
+
+		Convey("every floor should be created with its own rows", func() {
+			So(data, ShouldHaveLength, 3*(2*val.Width+1))
+			So(val.getCellsCount(), ShouldEqual, 144)
+		})
+
+		Convey("every cell should have its own address on its floor", func() {
+			for cell := 1; cell <= val.getCellsCount(); cell++ {
+				So(val.getCellNumber(val.getCellAddress(cell).MiddleCenter), ShouldEqual, cell)
+			}
+		})
+
+		Convey("the floors should be joined by stairs and every cell should be reachable", func() {
+			neighbors := val.getCellNeighbors(50)
+			So(neighbors.Above, ShouldEqual, 98)
+			So(neighbors.Below, ShouldEqual, 2)
+			So(neighbors.Top, ShouldEqual, 0)
+
+			So(val.getDistances(data, val.getCellNumber(val.StartPosition)), ShouldHaveLength, val.getCellsCount())
+		})
+	})
+}
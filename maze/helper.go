@@ -25,14 +25,23 @@ type (
 		TopRight     []int
 	}
 
-	// cellNeighbors defines the four nieghbors that may surround a given cell.
-	// Cells along the maze edges have two to three nieghbors but cells at the center
-	// of the maze have four neighbors.
+	// cellNeighbors defines the nieghbors that may surround a given cell.
+	// Square cells along the maze edges have two to three nieghbors but cells at the center
+	// of the maze have four neighbors. Hexagonal cells use Top and Bottom together with the
+	// upper and lower side neighbors while triangular cells only use one of Top and Bottom.
+	// Above and Below are the cells on the floors above and below the given cell and are
+	// only found in multi-floor mazes.
 	cellNeighbors struct {
-		Bottom int
-		Left   int
-		Right  int
-		Top    int
+		Above      int
+		Below      int
+		Bottom     int
+		Left       int
+		LowerLeft  int
+		LowerRight int
+		Right      int
+		Top        int
+		UpperLeft  int
+		UpperRight int
 	}
 )
 
@@ -52,90 +61,119 @@ func (config *Dimensions) createPlayingField(intensity int) ([][]string, error)
 		return data, err
 	}
 
-	for i := 0; i < (2*config.Width)+1; i++ {
-		var val []string
-
-		for k := 0; k < config.Length+1; k++ {
-			val = append(val, chars[0])
-
-			switch {
-			case k != config.Length && i%2 == 0:
-				val = append(val, chars[1])
-			case k != config.Length && i%2 != 0:
-				val = append(val, "   ")
-			default:
-				val = append(val, "\n")
-			}
-		}
-
-		data = append(data, val)
+	// Every floor is created below the previous one with its own top and bottom walls.
+	for floor := 0; floor < config.getFloors(); floor++ {
+		data = append(data, config.getTopology().createFloor(config, chars)...)
 	}
+
 	return data, nil
 }
 
 // getCellAddress creates and returns the cell address of the provided cell.
 // A cell address is defined by the nine coordinates, where each of them represents the
 // actual position of a terminal printable character that becomes a part of the maze.
+// The cells of the upper floors are found below the rows of the floors beneath them.
 func (config *Dimensions) getCellAddress(cellNo int) cellAddress {
-	var len int
-
-	if cellNo > (config.Length * config.Width) {
+	if cellNo < 1 || cellNo > config.getCellsCount() {
 		return cellAddress{}
 	}
 
-	if len = cellNo % config.Length; len == 0 {
-		len = config.Length
-	}
+	floor := (cellNo - 1) / (config.Length * config.Width)
+	center := config.getTopology().getCenter(config, cellNo-floor*config.Length*config.Width)
 
-	var wid = getCeiledDivisor(cellNo, config.Length) * 2
-	len = len * 2
+	wid, len := center[0]+floor*config.getFloorRows(), center[1]
 
 	return cellAddress{
-		BottomCenter: []int{wid, len - 1},
-		BottomLeft:   []int{wid, len - 2},
-		BottomRight:  []int{wid, len},
-		MiddleCenter: []int{wid - 1, len - 1},
-		MiddleLeft:   []int{wid - 1, len - 2},
-		MiddleRight:  []int{wid - 1, len},
-		TopCenter:    []int{wid - 2, len - 1},
-		TopLeft:      []int{wid - 2, len - 2},
-		TopRight:     []int{wid - 2, len},
+		BottomCenter: []int{wid + 1, len},
+		BottomLeft:   []int{wid + 1, len - 1},
+		BottomRight:  []int{wid + 1, len + 1},
+		MiddleCenter: []int{wid, len},
+		MiddleLeft:   []int{wid, len - 1},
+		MiddleRight:  []int{wid, len + 1},
+		TopCenter:    []int{wid - 1, len},
+		TopLeft:      []int{wid - 1, len - 1},
+		TopRight:     []int{wid - 1, len + 1},
 	}
 }
 
 // getCellNeighbors fetches all the possible neighbors of the provided cell.
+// The cells on the floors above and below are only neighbors in multi-floor mazes
+// while the cells masked out by the maze shape are never neighbors.
 func (config *Dimensions) getCellNeighbors(cellNo int) cellNeighbors {
-	if cellNo > (config.Length * config.Width) {
+	if cellNo < 1 || cellNo > config.getCellsCount() || config.masked[cellNo] {
 		return cellNeighbors{}
 	}
 
 	var (
-		right     = cellNo + 1
-		left      = cellNo - 1
-		top       = cellNo - config.Length
-		bottom    = cellNo + config.Length
-		neighbors = cellNeighbors{}
+		area   = config.Length * config.Width
+		floor  = (cellNo - 1) / area
+		offset = floor * area
+
+		neighbors = config.getTopology().getNeighbors(config, cellNo-offset)
 	)
 
-	if getCeiledDivisor(right, config.Length) == getCeiledDivisor(cellNo, config.Length) {
-		neighbors.Right = right
+	for _, neighbor := range []*int{&neighbors.Bottom, &neighbors.Left, &neighbors.LowerLeft,
+		&neighbors.LowerRight, &neighbors.Right, &neighbors.Top, &neighbors.UpperLeft, &neighbors.UpperRight} {
+		if *neighbor != 0 {
+			*neighbor += offset
+		}
 	}
 
-	if getCeiledDivisor(left, config.Length) == getCeiledDivisor(cellNo, config.Length) {
-		neighbors.Left = left
+	if floor+1 < config.getFloors() {
+		neighbors.Above = cellNo + area
 	}
 
-	if top > 0 {
-		neighbors.Top = top
+	if floor > 0 {
+		neighbors.Below = cellNo - area
 	}
 
-	if bottom <= (config.Length * config.Width) {
-		neighbors.Bottom = bottom
+	for _, neighbor := range []*int{&neighbors.Above, &neighbors.Below, &neighbors.Bottom, &neighbors.Left,
+		&neighbors.LowerLeft, &neighbors.LowerRight, &neighbors.Right, &neighbors.Top, &neighbors.UpperLeft,
+		&neighbors.UpperRight} {
+		if config.masked[*neighbor] {
+			*neighbor = 0
+		}
 	}
 
 	return neighbors
 }
 
+// getDirections returns the directions of the sides of the provided cell in clockwise order.
+func (config *Dimensions) getDirections(cellNo int) []string {
+	return config.getTopology().getDirections(config, (cellNo-1)%(config.Length*config.Width)+1)
+}
+
+// getNeighborCells returns the neighbors of the provided cell found in any direction,
+// including the floors above and below.
+func (config *Dimensions) getNeighborCells(cellNo int) []int {
+	var cells []int
+
+	neighbors := config.getCellNeighbors(cellNo)
+
+	for _, direction := range append(neighborDirections, floorDirections...) {
+		if neighbor := getNeighbor(neighbors, direction); neighbor != 0 {
+			cells = append(cells, neighbor)
+		}
+	}
+
+	return cells
+}
+
+// getFloorNeighbors returns the neighbors of the provided cell found on the same floor.
+func (config *Dimensions) getFloorNeighbors(cellNo int) []int {
+	var cells []int
+
+	neighbors := config.getCellNeighbors(cellNo)
+
+	for _, direction := range neighborDirections {
+		if neighbor := getNeighbor(neighbors, direction); neighbor != 0 {
+			cells = append(cells, neighbor)
+		}
+	}
+
+	return cells
+}
+
 // getRandomNo returns a random number generated from
 // the current timestamp and should be less the max value
 // provided and greater than or equal to zero. (0 <= X < max)
@@ -170,3 +208,13 @@ func getWallCharacters(intensity int) ([]string, error) {
 func isSpaceFound(item string) bool {
 	return strings.Contains(item, " ")
 }
+
+// isCellFound checks if the provided cell number exists in the cells slice.
+func isCellFound(cells []int, cellNo int) bool {
+	for _, cell := range cells {
+		if cell == cellNo {
+			return true
+		}
+	}
+	return false
+}
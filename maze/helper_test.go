@@ -166,6 +166,17 @@ func TestGetCellNeighbors(t *testing.T) {
 				So(expected.Right, ShouldEqual, found.Right)
 			}
 		})
+
+		Convey("The cells on the floors above and below should only be neighbors "+
+			"in multi-floor mazes", func() {
+			So(val.getCellNeighbors(8).Above, ShouldEqual, 0)
+			So(val.getCellNeighbors(8).Below, ShouldEqual, 0)
+
+			layered := &Dimensions{Length: 6, Width: 5, Depth: 2}
+
+			So(layered.getCellNeighbors(8), ShouldResemble, cellNeighbors{Above: 38, Top: 2, Right: 9, Bottom: 14, Left: 7})
+			So(layered.getCellNeighbors(32), ShouldResemble, cellNeighbors{Below: 2, Right: 33, Bottom: 38, Left: 31})
+		})
 	})
 }
 
@@ -265,3 +276,14 @@ func TestIsSpaceFound(t *testing.T) {
 		})
 	})
 }
+
+// TestIsCellFound tests the functionality of isCellFound
+func TestIsCellFound(t *testing.T) {
+	Convey("TestIsCellFound: Given a slice of cells and a cell number", t, func() {
+		Convey("the correct boolean should be returned depending on whether the cell exists", func() {
+			So(isCellFound([]int{1, 5, 9}, 5), ShouldBeTrue)
+			So(isCellFound([]int{1, 5, 9}, 4), ShouldBeFalse)
+			So(isCellFound(nil, 1), ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,38 @@
+package maze
+
+// moveTarget moves the target a single step away from the player. The target heads towards
+// the cell that is farthest from the player among the cells it can reach before the player
+// does, preferring cells with more paths to escape through. The target stays in place if
+// it is already at such a cell or it has been cornered. Doors are treated as walls, so
+// the target never crosses one.
+func (config *Dimensions) moveTarget(maze [][]string) {
+	var (
+		targetCell = config.getCellNumber(config.FinalPosition)
+		playerCell = config.getCellNumber(config.StartPosition)
+
+		goal, bestScore = targetCell, -1
+	)
+
+	if targetCell == playerCell {
+		return
+	}
+
+	playerDist := config.getDistances(maze, playerCell)
+
+	for cell, distance := range config.getDistances(maze, targetCell) {
+		// The player can get to the cell first.
+		if distance >= playerDist[cell] {
+			continue
+		}
+
+		score := playerDist[cell]*4 + len(config.getOpenNeighbors(maze, cell))
+
+		if score > bestScore || (score == bestScore && cell < goal) {
+			goal, bestScore = cell, score
+		}
+	}
+
+	if path := config.solveMaze(maze, targetCell, goal); len(path) > 1 {
+		config.FinalPosition = config.getCellAddress(path[1]).MiddleCenter
+	}
+}
@@ -0,0 +1,33 @@
+package maze
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestMoveTarget tests the functionality of moveTarget
+func TestMoveTarget(t *testing.T) {
+	var testFunc = func(playerCell, targetCell, expectedCell int) {
+		val := &Dimensions{Length: 3, Width: 3}
+		val.StartPosition = val.getCellAddress(playerCell).MiddleCenter
+		val.FinalPosition = val.getCellAddress(targetCell).MiddleCenter
+
+		val.moveTarget(testMaze)
+
+		So(val.getCellNumber(val.FinalPosition), ShouldEqual, expectedCell)
+	}
+
+	Convey("TestMoveTarget: Given the player and the target positions", t, func() {
+		Convey("where the target can escape, it should move away from the player", func() {
+			testFunc(1, 5, 6)
+			testFunc(9, 5, 2)
+		})
+
+		Convey("where the target is cornered or caught, it should not move", func() {
+			testFunc(1, 8, 8)
+			testFunc(9, 8, 8)
+			testFunc(5, 5, 5)
+		})
+	})
+}
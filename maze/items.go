@@ -0,0 +1,265 @@
+package maze
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// coinItem defines the cell content of a coin that adds to the scores.
+	coinItem = " $ "
+
+	// timeItem defines the cell content of a time bonus that extends the time left.
+	timeItem = " + "
+
+	// emptyCell defines the content of a cell without an item.
+	emptyCell = "   "
+)
+
+// doorKeys defines the keys that can be placed in the maze. A key is displayed as a lowercase
+// letter on a cell while its matching door is displayed as the same uppercase letter on the
+// wall that it replaces. Each key and its door share the same color.
+var doorKeys = []string{"a", "b", "c", "d"}
+
+// getDoorKey returns the key that opens the provided wall if it is a door.
+// An empty string is returned if the wall is not a door.
+func getDoorKey(wall string) string {
+	char, _ := utf8.DecodeRuneInString(wall)
+
+	if !unicode.IsUpper(char) || wall != strings.Repeat(string(char), utf8.RuneCountInString(wall)) {
+		return ""
+	}
+
+	return strings.ToLower(string(char))
+}
+
+// getCellKey returns the key found on the provided cell content.
+// An empty string is returned if the cell has no key.
+func getCellKey(cell string) string {
+	for _, key := range doorKeys {
+		if cell == " "+key+" " {
+			return key
+		}
+	}
+	return ""
+}
+
+// placeItems places coins, time bonuses, keys and doors in the maze. The doors are placed
+// across the shortest path to the target while every key is placed on a cell that can be
+// reached using only the keys placed before it, so the target can always be located.
+// The path length is then updated to include the detours made to collect the keys.
+func (config *Dimensions) placeItems(maze [][]string) {
+	var (
+		keyCells []int
+
+		area  = config.getCellsCount()
+		start = config.getCellNumber(config.StartPosition)
+		path  = config.solveMaze(maze, start, config.getCellNumber(config.FinalPosition))
+		keys  = map[string]bool{}
+
+		doors = 1 + area/150
+	)
+
+	if doors > len(doorKeys) {
+		doors = len(doorKeys)
+	}
+
+	// Doors can only replace the open walls between adjacent cells of the path, so the
+	// first step, the jumps made through teleporters and the tunnels under bridges are skipped.
+	var passages []int
+
+	for step := 1; step < len(path)-1; step++ {
+		if wall, _ := config.getCommonWall(path[step], path[step+1]); wall != nil && isSpaceFound(maze[wall[0]][wall[1]]) {
+			passages = append(passages, step)
+		}
+	}
+
+	segment := len(passages) / doors
+	if segment <= 0 {
+		doors = 0
+	}
+
+	// Doors are placed on distinct passages of the path, ordered from the start to the target.
+	for i, key := range doorKeys[:doors] {
+		step := passages[i*segment+getRandomNo(segment)]
+		config.setWall(maze, path[step], path[step+1], strings.ToUpper(key))
+	}
+
+	for _, key := range doorKeys[:doors] {
+		cells := config.getFreeCells(maze, config.getReachableCells(maze, start, keys))
+
+		if len(cells) == 0 {
+			openDoors(maze, key)
+			continue
+		}
+
+		keyCells = append(keyCells, cells[getRandomNo(len(cells))])
+		config.setCell(maze, keyCells[len(keyCells)-1], " "+key+" ")
+		keys[key] = true
+	}
+
+	config.PathLength = config.getKeysPathLength(maze, keyCells)
+
+	for item, count := range map[string]int{coinItem: area / 15, timeItem: area / 40} {
+		cells := config.getFreeCells(maze, config.getReachableCells(maze, start, keys))
+
+		for ; count > 0 && len(cells) > 0; count-- {
+			index := getRandomNo(len(cells))
+			config.setCell(maze, cells[index], item)
+			cells = append(cells[:index], cells[index+1:]...)
+		}
+	}
+}
+
+// getKeysPathLength returns the number of moves made from the starting point to the target
+// while collecting the keys on the provided cells in order. Every door is only crossed once
+// its key has been collected.
+func (config *Dimensions) getKeysPathLength(maze [][]string, keyCells []int) int {
+	var (
+		length int
+
+		cell = config.getCellNumber(config.StartPosition)
+		keys = map[string]bool{}
+	)
+
+	for _, next := range append(keyCells, config.getCellNumber(config.FinalPosition)) {
+		length += config.getPassableDistances(maze, cell, keys)[next]
+		keys[getCellKey(config.getCellContent(maze, next))] = true
+		cell = next
+	}
+
+	return length
+}
+
+// getFreeCells returns the cells among the provided cells without any item,
+// excluding the starting point and the target.
+func (config *Dimensions) getFreeCells(maze [][]string, cells map[int]bool) []int {
+	var freeCells []int
+
+	for cell := 1; cell <= config.getCellsCount(); cell++ {
+		pos := config.getCellAddress(cell).MiddleCenter
+
+		if cells[cell] && maze[pos[0]][pos[1]] == emptyCell &&
+			cell != config.getCellNumber(config.StartPosition) &&
+			cell != config.getCellNumber(config.FinalPosition) {
+			freeCells = append(freeCells, cell)
+		}
+	}
+
+	return freeCells
+}
+
+// setCell replaces the content of the provided cell.
+func (config *Dimensions) setCell(maze [][]string, cellNo int, content string) {
+	pos := config.getCellAddress(cellNo).MiddleCenter
+	maze[pos[0]][pos[1]] = content
+}
+
+// setWall replaces the common wall between the two provided cells with the
+// provided character repeated to the width of the wall. Nothing is replaced if the
+// cells do not share a wall, e.g. when they are joined by stairs.
+func (config *Dimensions) setWall(maze [][]string, cellNo, neighbor int, char string) {
+	for _, wall := range config.getCommonWalls(cellNo, neighbor) {
+		maze[wall[0]][wall[1]] = strings.Repeat(char, utf8.RuneCountInString(maze[wall[0]][wall[1]]))
+	}
+}
+
+// getReachableCells returns the cells that can be reached from the provided cell
+// using only the doors whose keys are provided.
+func (config *Dimensions) getReachableCells(maze [][]string, startCell int, keys map[string]bool) map[int]bool {
+	var (
+		current int
+
+		queue     = []int{startCell}
+		reachable = map[int]bool{startCell: true}
+	)
+
+	for len(queue) > 0 {
+		current, queue = queue[0], queue[1:]
+
+		for _, neighbor := range config.getPassableNeighbors(maze, current, keys) {
+			if !reachable[neighbor] {
+				reachable[neighbor] = true
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	return reachable
+}
+
+// isTargetReachable checks if the target can be reached from the current player position
+// by collecting the keys that can be reached and opening their matching doors.
+func (config *Dimensions) isTargetReachable(maze [][]string) bool {
+	keys := map[string]bool{}
+
+	for {
+		found := false
+		reachable := config.getReachableCells(maze, config.getCellNumber(config.StartPosition), keys)
+
+		if reachable[config.getCellNumber(config.FinalPosition)] {
+			return true
+		}
+
+		for cell := range reachable {
+			pos := config.getCellAddress(cell).MiddleCenter
+
+			if key := getCellKey(maze[pos[0]][pos[1]]); key != "" && !keys[key] {
+				keys[key], found = true, true
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+}
+
+// getNearestKey returns the closest key that can be reached from the provided cell.
+// Zero is returned if no key can be reached.
+func (config *Dimensions) getNearestKey(maze [][]string, cellNo int) int {
+	nearest, minDist := 0, -1
+
+	for cell, distance := range config.getDistances(maze, cellNo) {
+		pos := config.getCellAddress(cell).MiddleCenter
+
+		if getCellKey(maze[pos[0]][pos[1]]) != "" && (minDist < 0 || distance < minDist) {
+			nearest, minDist = cell, distance
+		}
+	}
+
+	return nearest
+}
+
+// collectItem collects the item found on the current player position and returns its kind.
+// Keys open their matching doors once collected. Plain is returned if no item is found.
+func (config *Dimensions) collectItem(data [][]string) Kind {
+	pos := config.StartPosition
+	kind, key := getKind(data[pos[0]][pos[1]])
+
+	switch kind {
+	case Coin, TimeBonus:
+
+	case Key:
+		openDoors(data, key)
+
+	default:
+		return Plain
+	}
+
+	data[pos[0]][pos[1]] = emptyCell
+
+	return kind
+}
+
+// openDoors replaces all the doors matching the provided key with paths.
+func openDoors(data [][]string, key string) {
+	for _, line := range data {
+		for index, wall := range line {
+			if getDoorKey(wall) == key {
+				line[index] = strings.Repeat(" ", utf8.RuneCountInString(wall))
+			}
+		}
+	}
+}
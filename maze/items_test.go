@@ -0,0 +1,273 @@
+package maze
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// copyMaze returns a copy of the provided maze that can be modified by a test.
+func copyMaze(data [][]string) [][]string {
+	view := make([][]string, len(data))
+
+	for i, line := range data {
+		view[i] = append([]string{}, line...)
+	}
+
+	return view
+}
+
+// TestGetDoorKey tests the functionality of getDoorKey
+func TestGetDoorKey(t *testing.T) {
+	Convey("TestGetDoorKey: Given a wall", t, func() {
+		Convey("that is a door, the key that opens it should be returned", func() {
+			So(getDoorKey("A"), ShouldEqual, "a")
+			So(getDoorKey("CCC"), ShouldEqual, "c")
+		})
+
+		Convey("that is not a door, an empty string should be returned", func() {
+			for _, wall := range []string{"", " ", "   ", "|", "---", "═", " a ", "AB"} {
+				So(getDoorKey(wall), ShouldBeEmpty)
+			}
+		})
+	})
+}
+
+// TestGetCellKey tests the functionality of getCellKey
+func TestGetCellKey(t *testing.T) {
+	Convey("TestGetCellKey: Given the cell content, the key found should be returned", t, func() {
+		So(getCellKey(" a "), ShouldEqual, "a")
+		So(getCellKey(" d "), ShouldEqual, "d")
+		So(getCellKey(" z "), ShouldBeEmpty)
+		So(getCellKey(coinItem), ShouldBeEmpty)
+		So(getCellKey(emptyCell), ShouldBeEmpty)
+	})
+}
+
+// TestPlaceItems tests the functionality of placeItems
+func TestPlaceItems(t *testing.T) {
+	Convey("TestPlaceItems: Given a perfect maze", t, func() {
+		val := &Dimensions{Length: 20, Width: 16}
+		data, err := val.generateMaze(1, Difficulty{})
+
+		So(err, ShouldBeNil)
+
+		val.placeItems(data)
+
+		var coins, bonuses, keys, doors int
+
+		for _, line := range data {
+			for _, char := range line {
+				switch {
+				case char == coinItem:
+					coins++
+				case char == timeItem:
+					bonuses++
+				case getCellKey(char) != "":
+					keys++
+				case getDoorKey(char) != "":
+					doors++
+				}
+			}
+		}
+
+		Convey("coins, time bonuses, keys and doors should be placed", func() {
+			So(coins, ShouldEqual, 320/15)
+			So(bonuses, ShouldEqual, 320/40)
+			So(keys, ShouldEqual, 3)
+			So(doors, ShouldEqual, keys)
+		})
+
+		Convey("the doors should block the only path to the target", func() {
+			So(val.solveMaze(data, val.getCellNumber(val.StartPosition),
+				val.getCellNumber(val.FinalPosition)), ShouldBeEmpty)
+		})
+
+		Convey("the target should be reachable by collecting the keys", func() {
+			So(val.isTargetReachable(data), ShouldBeTrue)
+		})
+
+		Convey("the time budget should cover collecting the keys in order", func() {
+			var (
+				route int
+
+				cell     = val.getCellNumber(val.StartPosition)
+				keyCells = map[string]int{}
+				view     = copyMaze(data)
+			)
+
+			for cellNo := 1; cellNo <= val.getCellsCount(); cellNo++ {
+				if key := getCellKey(val.getCellContent(data, cellNo)); key != "" {
+					keyCells[key] = cellNo
+				}
+			}
+
+			for _, key := range doorKeys {
+				if keyCell, ok := keyCells[key]; ok {
+					route += len(val.solveMaze(view, cell, keyCell)) - 1
+					openDoors(view, key)
+					cell = keyCell
+				}
+			}
+
+			route += len(val.solveMaze(view, cell, val.getCellNumber(val.FinalPosition))) - 1
+
+			So(val.PathLength, ShouldEqual, route)
+			So(presets["insane"].getTimeBudget(val.PathLength), ShouldBeGreaterThanOrEqualTo, route)
+		})
+	})
+
+	Convey("TestPlaceItems: Given a maze whose shortest path jumps through a teleporter", t, func() {
+		val := &Dimensions{Length: 3, Width: 3}
+		val.StartPosition = val.getCellAddress(1).MiddleCenter
+		val.FinalPosition = val.getCellAddress(9).MiddleCenter
+
+		data := copyMaze(testMaze)
+		val.addTeleporters(data, 5, 8, " 1 ")
+
+		So(val.solveMaze(data, 1, 9), ShouldResemble, []int{1, 2, 8, 9})
+		So(func() { val.placeItems(data) }, ShouldNotPanic)
+
+		Convey("the door should only be placed between adjacent cells", func() {
+			So(data[5][4], ShouldEqual, "A")
+		})
+
+		Convey("the target should be reachable by collecting the keys", func() {
+			So(val.isTargetReachable(data), ShouldBeTrue)
+		})
+	})
+
+	Convey("TestPlaceItems: Given a maze whose shortest path passes through a tunnel", t, func() {
+		val := &Dimensions{Length: 3, Width: 3}
+		val.StartPosition = val.getCellAddress(1).MiddleCenter
+		val.FinalPosition = val.getCellAddress(9).MiddleCenter
+
+		data := [][]string{
+			{"|", "---", "|", "---", "|", "---", "|"},
+			{"|", "   ", " ", "   ", "|", "   ", "|"},
+			{"|", "---", "|", "   ", "|", "---", "|"},
+			{"|", "   ", " ", horizontalBridge, " ", "   ", "|"},
+			{"|", "---", "|", "   ", "|", "---", "|"},
+			{"|", "   ", "|", "   ", " ", "   ", "|"},
+			{"|", "---", "|", "---", "|", "---", "|"},
+		}
+
+		So(val.solveMaze(data, 1, 9), ShouldResemble, []int{1, 2, 8, 9})
+		So(func() { val.placeItems(data) }, ShouldNotPanic)
+
+		Convey("the door should only be placed between adjacent cells", func() {
+			So(data[5][4], ShouldEqual, "A")
+		})
+
+		Convey("the target should be reachable by collecting the keys", func() {
+			So(val.isTargetReachable(data), ShouldBeTrue)
+		})
+	})
+}
+
+// TestGetKeysPathLength tests the functionality of getKeysPathLength
+func TestGetKeysPathLength(t *testing.T) {
+	Convey("TestGetKeysPathLength: Given a maze", t, func() {
+		val := &Dimensions{Length: 3, Width: 3}
+		val.StartPosition = val.getCellAddress(1).MiddleCenter
+		val.FinalPosition = val.getCellAddress(9).MiddleCenter
+
+		data := copyMaze(testMaze)
+
+		Convey("without keys, the shortest path to the target should be used", func() {
+			So(val.getKeysPathLength(data, nil), ShouldEqual, 4)
+		})
+
+		Convey("with a key away from the shortest path, the detour should be included", func() {
+			val.setWall(data, 5, 6, "A")
+			val.setCell(data, 7, " a ")
+
+			So(val.getKeysPathLength(data, []int{7}), ShouldEqual, 8)
+		})
+	})
+}
+
+// TestIsTargetReachable tests the functionality of isTargetReachable
+func TestIsTargetReachable(t *testing.T) {
+	Convey("TestIsTargetReachable: Given a maze with doors", t, func() {
+		val := &Dimensions{Length: 3, Width: 3}
+		val.StartPosition = val.getCellAddress(1).MiddleCenter
+		val.FinalPosition = val.getCellAddress(9).MiddleCenter
+
+		data := copyMaze(testMaze)
+		val.setWall(data, 5, 6, "A")
+
+		Convey("where the key is reachable, true should be returned", func() {
+			val.setCell(data, 7, " a ")
+			So(val.isTargetReachable(data), ShouldBeTrue)
+		})
+
+		Convey("where the key is behind its own door, false should be returned", func() {
+			val.setCell(data, 3, " a ")
+			So(val.isTargetReachable(data), ShouldBeFalse)
+		})
+	})
+}
+
+// TestCollectItem tests the functionality of collectItem
+func TestCollectItem(t *testing.T) {
+	Convey("TestCollectItem: Given the player position", t, func() {
+		val := &Dimensions{Length: 3, Width: 3}
+		data := copyMaze(testMaze)
+
+		val.setWall(data, 5, 6, "A")
+		val.setWall(data, 1, 4, "B")
+
+		for cell, item := range map[int]string{2: coinItem, 4: timeItem, 7: " a "} {
+			val.setCell(data, cell, item)
+		}
+
+		Convey("on a coin, the coin should be collected", func() {
+			val.StartPosition = val.getCellAddress(2).MiddleCenter
+
+			So(val.collectItem(data), ShouldEqual, Coin)
+			So(data[1][3], ShouldEqual, emptyCell)
+		})
+
+		Convey("on a time bonus, the time bonus should be collected", func() {
+			val.StartPosition = val.getCellAddress(4).MiddleCenter
+
+			So(val.collectItem(data), ShouldEqual, TimeBonus)
+			So(data[3][1], ShouldEqual, emptyCell)
+		})
+
+		Convey("on a key, only the matching doors should be opened", func() {
+			val.StartPosition = val.getCellAddress(7).MiddleCenter
+
+			So(val.collectItem(data), ShouldEqual, Key)
+			So(data[3][4], ShouldEqual, " ")
+			So(data[2][1], ShouldEqual, "BBB")
+			So(val.getOpenNeighbors(data, 5), ShouldContain, 6)
+		})
+
+		Convey("on an empty cell, nothing should change", func() {
+			val.StartPosition = val.getCellAddress(9).MiddleCenter
+
+			So(val.collectItem(data), ShouldEqual, Plain)
+			So(data[5][5], ShouldEqual, emptyCell)
+			So(strings.Join(data[5], ""), ShouldEqual, "| a |       |")
+		})
+	})
+}
+
+// TestGetNearestKey tests the functionality of getNearestKey
+func TestGetNearestKey(t *testing.T) {
+	Convey("TestGetNearestKey: Given a cell, the closest reachable key should be returned", t, func() {
+		val := &Dimensions{Length: 3, Width: 3}
+		data := copyMaze(testMaze)
+
+		So(val.getNearestKey(data, 1), ShouldEqual, 0)
+
+		val.setCell(data, 8, " b ")
+		val.setCell(data, 3, " a ")
+
+		So(val.getNearestKey(data, 1), ShouldEqual, 3)
+		So(val.getNearestKey(data, 9), ShouldEqual, 8)
+	})
+}
@@ -1,15 +1,16 @@
 package maze
 
 import (
-	"errors"
+	"fmt"
 	"math"
 )
 
-// seed defines the size of the maze to be used in the training level (level 0).
-// It can also be referred to as the size of the training field.
+// seed defines the size of the maze to be used in the training level (level 0) of the
+// normal difficulty. It can also be referred to as the size of the training field.
 const seed = 100
 
-// diff defines the difference between maze sizes in consecutive game levels.
+// diff defines the difference between maze sizes in consecutive game levels of the
+// normal difficulty.
 const diff = 10
 
 // maxLevel defines the maximum level that can be played in this game.
@@ -17,69 +18,133 @@ const diff = 10
 // for users with smaller screen sizes.
 const maxLevel = 290
 
-// generateMazeArea generates the full maze size depending on the provided game level.
-func generateMazeArea(level int) float64 {
+// generateMazeArea generates the full maze size depending on the provided game level
+// and the growth values of the difficulty preset used.
+func generateMazeArea(level int, preset Difficulty) float64 {
 	// Level larger than maxLevel should never be used
 	if level >= maxLevel {
 		level = maxLevel
 	}
-	return float64((level * diff) + seed)
+	return float64((level * preset.Diff) + preset.Seed)
 }
 
-// appendFunc appends the valid dimensions to the size array
-func appendFunc(remaider float64, x, y int, tSize Dimensions) []Dimensions {
-	items := make([]Dimensions, 0)
+// minSide defines the smallest number of cells allowed along either edge of the maze.
+const minSide = 5
 
-	if remaider == 0 && (tSize.Length >= y) && (tSize.Width >= x) {
-		items = append(items, Dimensions{Length: y, Width: x})
-	}
+// areaTolerance defines the fraction by which the maze area may differ from the area
+// generated for the level so that sizes close to the target aspect ratio can be used.
+const areaTolerance = 0.1
+
+// getAspectRatio returns the ratio of the maze length to its width that the maze size should
+// be close to. Zero selects the aspect ratio of the provided terminal size.
+// If a negative ratio is used an error is thrown.
+func getAspectRatio(ratio float64, terminalSize Dimensions) (float64, error) {
+	switch {
+	case ratio < 0:
+		return 0, fmt.Errorf("Invalid aspect ratio found: %g. Allowed 0 for the terminal aspect ratio or above", ratio)
+
+	case ratio == 0 && terminalSize.Width > 0 && terminalSize.Length > 0:
+		return float64(terminalSize.Length) / float64(terminalSize.Width), nil
 
-	if remaider == 0 && (tSize.Length >= x) && (tSize.Width >= y) {
-		items = append(items, Dimensions{Length: x, Width: y})
+	case ratio == 0:
+		return 1, nil
 	}
 
-	return items
+	return ratio, nil
 }
 
-// factorizeMazeArea factorizes the MazeArea using the trial division algorithm
-// to get all possible factors for the length and the width values.
-// The smallest value of either length or width can only be 5.
-func factorizeMazeArea(mazeArea float64, c Dimensions) []Dimensions {
-	var size = make([]Dimensions, 0)
-
-	for i := int(math.Sqrt(mazeArea)); i > 4; i-- {
-		remaider := math.Remainder(mazeArea, float64(i))
-		val := int(mazeArea) / i
+// getIdealDimensions returns the length and the width whose ratio is closest to the provided
+// aspect ratio for the provided maze area, ignoring the terminal size.
+func getIdealDimensions(mazeArea, aspect float64) Dimensions {
+	width := int(math.Round(math.Sqrt(mazeArea / aspect)))
+	if width < minSide {
+		width = minSide
+	}
 
-		size = append(size, appendFunc(remaider, i, val, c)...)
+	length := int(math.Round(mazeArea / float64(width)))
+	if length < minSide {
+		length = minSide
 	}
 
-	return size
+	return Dimensions{Length: length, Width: width}
 }
 
-// getMazeDimensions obtains the best length and width measurements for the
-// current level and terminal size provided.
-func getMazeDimensions(level int, terminalSize Dimensions) (*Dimensions, error) {
-	area := generateMazeArea(level)
-	errMsg := "terminal size is too small for the current level"
+// getMazeDimensions obtains the length and width measurements for the current level and
+// difficulty preset that fit inside the terminal size provided. The area of the maze may differ
+// from the level area by the area tolerance, the measurements closest to the provided aspect
+// ratio are used. If no measurements fit, an error explaining the terminal size needed is thrown.
+func getMazeDimensions(level int, preset Difficulty, terminalSize Dimensions, aspect float64) (*Dimensions, error) {
+	var (
+		best *Dimensions
+
+		area       = generateMazeArea(level, preset)
+		bestOffset = math.Inf(1)
+	)
+
+	for width := minSide; width <= terminalSize.Width; width++ {
+		length := int(math.Round(area / float64(width)))
+
+		if length < minSide || length > terminalSize.Length ||
+			math.Abs(float64(length*width)-area) > area*areaTolerance {
+			continue
+		}
+
+		// The offset from the aspect ratio is measured on a logarithmic scale so that mazes
+		// twice as long and twice as wide as the ratio are equally far from it.
+		if offset := math.Abs(math.Log(float64(length) / float64(width) / aspect)); offset < bestOffset {
+			best, bestOffset = &Dimensions{Length: length, Width: width}, offset
+		}
+	}
 
-	if int(area) > (terminalSize.Width * terminalSize.Length) {
-		return &Dimensions{}, errors.New(errMsg)
+	if best != nil {
+		return best, nil
 	}
 
-	dimensions := factorizeMazeArea(area, terminalSize)
-	totalCount := len(dimensions)
+	ideal := getIdealDimensions(area, aspect)
+	ideal.Topology = terminalSize.Topology
+	columns, rows := getTerminalCells(ideal)
 
-	for i := 0; i < totalCount; i++ {
-		return &dimensions[getRandomNo(totalCount)], nil
+	return &Dimensions{}, fmt.Errorf("terminal size is too small for level %d: a maze of %dx%d cells "+
+		"needs a terminal of at least %d columns and %d rows", level, ideal.Length, ideal.Width, columns, rows)
+}
+
+// LevelSize returns the length and the width of the maze used in the provided game level of
+// the provided difficulty preset. The maze of the provided topology fits inside a terminal with
+// the provided number of columns and rows and its aspect ratio is close to the one provided, zero
+// uses the aspect ratio of the terminal. If the maze cannot fit or the preset or ratio is invalid
+// an error is thrown.
+func LevelSize(level int, difficulty, topology string, columns, rows int, aspect float64) (length, width int, err error) {
+	preset, err := getDifficulty(difficulty)
+	if err != nil {
+		return 0, 0, err
 	}
 
-	// If the terminal size hasn't been minimized, It should never get here
-	return &Dimensions{}, errors.New(errMsg)
+	terminalSize := getTerminalSize(columns, rows, topology)
+
+	if aspect, err = getAspectRatio(aspect, terminalSize); err != nil {
+		return 0, 0, err
+	}
+
+	size, err := getMazeDimensions(level, preset, terminalSize, aspect)
+
+	return size.Length, size.Width, err
 }
 
 // getTerminalSize calculate the terminal size from the values captured by the
-// termbox.Size() function
-func getTerminalSize(h, w int) Dimensions {
-	return Dimensions{Length: (h - 5) / 4, Width: (w - 10) / 2}
+// termbox.Size() function for a maze of the provided topology.
+func getTerminalSize(h, w int, topology string) Dimensions {
+	size := Dimensions{Length: (h - 5) / 4, Width: (w - 10) / 2, Topology: topology}
+
+	// Some topologies, e.g. hexagonal cells, need more rows than square cells.
+	for size.Width > 0 && size.getFloorRows() > w-9 {
+		size.Width--
+	}
+
+	return size
+}
+
+// getTerminalCells returns the number of terminal columns and rows needed to display
+// a maze of the provided size. It reverses the calculation done by getTerminalSize.
+func getTerminalCells(size Dimensions) (columns, rows int) {
+	return (size.Length * 4) + 5, size.getFloorRows() + 9
 }
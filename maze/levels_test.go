@@ -10,7 +10,7 @@ import (
 func TestGenarateMazeArea(t *testing.T) {
 
 	var testFunc = func(level int, expectedOutput float64) {
-		val := generateMazeArea(level)
+		val := generateMazeArea(level, presets["normal"])
 
 		So(val, ShouldHaveSameTypeAs, expectedOutput)
 		So(val, ShouldEqual, expectedOutput)
@@ -31,46 +31,41 @@ func TestGenarateMazeArea(t *testing.T) {
 	})
 }
 
-// TestAppendFunc tests the functionality of appendFunc
-func TestAppendFunc(t *testing.T) {
-	var testFunc = func(rem float64, x, y, length int, tSize Dimensions) {
-		val := appendFunc(rem, x, y, tSize)
+// TestGetAspectRatio tests the functionality of getAspectRatio
+func TestGetAspectRatio(t *testing.T) {
+	Convey("TestGetAspectRatio: Given an aspect ratio", t, func() {
+		Convey("that is greater than zero, the same ratio should be returned", func() {
+			aspect, err := getAspectRatio(1.5, Dimensions{Length: 40, Width: 10})
 
-		So(len(val), ShouldEqual, length)
-		So(cap(val), ShouldEqual, length)
-	}
-
-	Convey("TestAppendFunc: Given values ", t, func() {
-		Convey("where the remaider is not zero, the returned slice should be empty", func() {
-			testFunc(4, 2, 4, 0, Dimensions{Length: 5, Width: 5})
+			So(err, ShouldBeNil)
+			So(aspect, ShouldEqual, 1.5)
 		})
 
-		Convey("where the remaider is zero but the x and y values are not within the terminal size, the returned slice should be empty ", func() {
-			testFunc(0, 6, 4, 0, Dimensions{Length: 5, Width: 5})
+		Convey("that is zero, the aspect ratio of the terminal should be returned", func() {
+			aspect, err := getAspectRatio(0, Dimensions{Length: 40, Width: 10})
+
+			So(err, ShouldBeNil)
+			So(aspect, ShouldEqual, 4)
 		})
 
-		Convey("where the remaider is zero the x and y values are within the terminal size, the returned slice should not be empty", func() {
-			testFunc(0, 2, 4, 2, Dimensions{Length: 5, Width: 5})
+		Convey("that is negative, an error should be returned", func() {
+			_, err := getAspectRatio(-2, Dimensions{Length: 40, Width: 10})
+
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "Invalid aspect ratio found: -2")
 		})
 	})
-
 }
 
-// TestFactorizeMazeArea tests the functionality of factorizeMazeArea
-func TestFactorizeMazeArea(t *testing.T) {
-	var testFunc = func(mazeArea float64, expectedSize int) {
-		area := factorizeMazeArea(mazeArea, Dimensions{Length: 30, Width: 20})
-
-		So(area, ShouldHaveLength, expectedSize)
-	}
-
-	Convey("TestFactorizeMazeArea: Given the mazeArea ", t, func() {
-		Convey("that can be factorized, several possible maze dimensions should be returned ", func() {
-			testFunc(100, 4)
+// TestGetIdealDimensions tests the functionality of getIdealDimensions
+func TestGetIdealDimensions(t *testing.T) {
+	Convey("TestGetIdealDimensions: Given the maze area and an aspect ratio", t, func() {
+		Convey("the dimensions closest to the aspect ratio should be returned", func() {
+			So(getIdealDimensions(200, 2), ShouldResemble, Dimensions{Length: 20, Width: 10})
 		})
 
-		Convey("that cannot be factorized, no possibilities that should be returned", func() {
-			testFunc(97, 0)
+		Convey("that would give a side shorter than the minimum, the minimum side should be used", func() {
+			So(getIdealDimensions(100, 100), ShouldResemble, Dimensions{Length: 20, Width: minSide})
 		})
 	})
 }
@@ -78,7 +73,7 @@ func TestFactorizeMazeArea(t *testing.T) {
 // TestGetMazeDimension tests the functionality of getMazeDimension
 func TestGetMazeDimension(t *testing.T) {
 	var testFunc = func(level int, size Dimensions, errMsg string) {
-		mazeSize, err := getMazeDimensions(level, size)
+		mazeSize, err := getMazeDimensions(level, presets["normal"], size, 1)
 
 		if len(errMsg) == 0 {
 			So(err, ShouldBeNil)
@@ -97,24 +92,95 @@ func TestGetMazeDimension(t *testing.T) {
 			testFunc(200, Dimensions{Length: 4, Width: 20}, "terminal size is too small")
 		})
 
-		Convey("where the maze area cannot fit the terminal size, the second value returned should implement the error interface", func() {
-			testFunc(0, Dimensions{Length: 100, Width: 1}, "terminal size is too small")
+		Convey("where the maze area cannot fit the terminal size, the error should explain the terminal size needed", func() {
+			testFunc(0, Dimensions{Length: 100, Width: 1}, "terminal size is too small for level 0: "+
+				"a maze of 10x10 cells needs a terminal of at least 45 columns and 30 rows")
 		})
 
 		Convey("where the maze area is less than the terminal and can be factored, the first value returned should be the dimensions to use", func() {
 			testFunc(1, Dimensions{Length: 20, Width: 10}, "")
 		})
+
+		Convey("where the maze area cannot be factored, the dimensions within the area tolerance should be returned", func() {
+			preset := Difficulty{Seed: 97}
+			mazeSize, err := getMazeDimensions(0, preset, Dimensions{Length: 30, Width: 20}, 1)
+
+			So(err, ShouldBeNil)
+			So(mazeSize, ShouldResemble, &Dimensions{Length: 10, Width: 10})
+		})
+
+		Convey("where an aspect ratio is provided, the dimensions closest to it should be returned", func() {
+			mazeSize, err := getMazeDimensions(0, presets["normal"], Dimensions{Length: 30, Width: 20}, 4)
+
+			So(err, ShouldBeNil)
+			So(mazeSize, ShouldResemble, &Dimensions{Length: 20, Width: 5})
+
+			mazeSize, err = getMazeDimensions(0, presets["normal"], Dimensions{Length: 12, Width: 20}, 4)
+
+			So(err, ShouldBeNil)
+			So(mazeSize, ShouldResemble, &Dimensions{Length: 11, Width: 9})
+		})
 	})
 
 }
 
+// TestLevelSize tests the functionality of LevelSize
+func TestLevelSize(t *testing.T) {
+	Convey("TestLevelSize: Given the level, the difficulty and the terminal size", t, func() {
+		Convey("that fit a maze, its length and width should be returned", func() {
+			length, width, err := LevelSize(1, "normal", SquareTopology, 201, 52, 2)
+
+			So(err, ShouldBeNil)
+			So(length*width, ShouldAlmostEqual, 110, 11)
+			So(length, ShouldBeGreaterThan, width)
+		})
+
+		Convey("that are invalid, an error should be returned", func() {
+			_, _, err := LevelSize(1, "impossible", SquareTopology, 201, 52, 0)
+			So(err, ShouldNotBeNil)
+
+			_, _, err = LevelSize(1, "normal", SquareTopology, 201, 52, -1)
+			So(err, ShouldNotBeNil)
+
+			_, _, err = LevelSize(1, "normal", SquareTopology, 20, 12, 0)
+			So(err.Error(), ShouldContainSubstring, "terminal size is too small for level 1")
+		})
+	})
+}
+
 // TestGetTerminalSize tests the functionality of getTerminalSize
 func TestGetTerminalSize(t *testing.T) {
 	Convey("TestGetTerminalSize: Given the actual terminal size ", t, func() {
 		Convey("It determines the largest possible size of the maze that can be created", func() {
-			val := getTerminalSize(202, 52)
+			val := getTerminalSize(202, 52, SquareTopology)
 			So(val.Length, ShouldEqual, 49)
 			So(val.Width, ShouldEqual, 21)
 		})
+
+		Convey("hexagonal cells should leave room for their extra row", func() {
+			val := getTerminalSize(202, 52, HexTopology)
+			So(val.Length, ShouldEqual, 49)
+			So(val.Width, ShouldEqual, 20)
+			So(val.getFloorRows(), ShouldBeLessThanOrEqualTo, 52-9)
+		})
+	})
+}
+
+// TestGetTerminalCells tests the functionality of getTerminalCells
+func TestGetTerminalCells(t *testing.T) {
+	Convey("TestGetTerminalCells: Given the size of a maze", t, func() {
+		Convey("the terminal size returned should fit the maze exactly", func() {
+			columns, rows := getTerminalCells(Dimensions{Length: 49, Width: 21})
+
+			So(columns, ShouldEqual, 201)
+			So(rows, ShouldEqual, 52)
+			So(getTerminalSize(columns, rows, ""), ShouldResemble, Dimensions{Length: 49, Width: 21})
+
+			columns, rows = getTerminalCells(Dimensions{Length: 49, Width: 21, Topology: HexTopology})
+
+			So(rows, ShouldEqual, 53)
+			So(getTerminalSize(columns, rows, HexTopology), ShouldResemble,
+				Dimensions{Length: 49, Width: 21, Topology: HexTopology})
+		})
 	})
 }
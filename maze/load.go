@@ -0,0 +1,211 @@
+package maze
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LoadMaze reads a maze of square cells written by Render using either the PlainTheme or the
+// SolutionTheme. The floors are separated by blank lines. The items and the crossings hidden
+// by the markers and the solution path are lost, so mazes with several floors or items should
+// be written using the PlainTheme. Mazes with shapes or edges that wrap around cannot be loaded.
+// If the text is not a rendered maze an error is thrown.
+func LoadMaze(r io.Reader) (*Maze, error) {
+	var (
+		floors [][]string
+		lines  []string
+	)
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		if line := strings.TrimRight(scanner.Text(), "\r"); line != "" {
+			lines = append(lines, line)
+			continue
+		}
+
+		if len(lines) > 0 {
+			floors, lines = append(floors, lines), nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(lines) > 0 {
+		floors = append(floors, lines)
+	}
+
+	config, err := getRenderedSize(floors)
+	if err != nil {
+		return nil, err
+	}
+
+	var grid [][]string
+
+	for _, floor := range floors {
+		for _, line := range floor {
+			grid = append(grid, splitRenderedLine(line))
+		}
+	}
+
+	if err = config.setMarkers(grid); err != nil {
+		return nil, err
+	}
+
+	return &Maze{
+		config: config,
+		grid:   grid,
+		preset: presets["normal"],
+		opts: Config{Length: config.Length, Width: config.Width, Floors: config.Depth,
+			Topology: SquareTopology, Difficulty: "normal", Intensity: getRenderedIntensity(grid)},
+	}, nil
+}
+
+// getRenderedIntensity returns the intensity whose wall characters draw the top wall of the
+// provided maze. If none of them matches, the thinnest walls are used.
+func getRenderedIntensity(grid [][]string) int {
+	for intensity := 3; intensity > 1; intensity-- {
+		if chars, _ := getWallCharacters(intensity); grid[0][1] == chars[1] {
+			return intensity
+		}
+	}
+
+	return 1
+}
+
+// getRenderedSize returns the dimensions of the maze drawn by the provided floors. Every floor
+// must have the same number of lines and every line the same number of characters.
+// If the floors do not draw a maze of square cells an error is thrown.
+func getRenderedSize(floors [][]string) (*Dimensions, error) {
+	if len(floors) == 0 {
+		return nil, fmt.Errorf("Invalid maze found: no lines. Allowed a rendered maze of 2x2 cells and above")
+	}
+
+	if lines := len(floors[0]); lines < 5 || lines%2 == 0 {
+		return nil, fmt.Errorf("Invalid maze found: %d lines per floor. Allowed an odd number of lines "+
+			"from 5 and above", lines)
+	}
+
+	columns := len([]rune(floors[0][0]))
+
+	for _, floor := range floors {
+		for _, line := range floor {
+			if len(floor) != len(floors[0]) || len([]rune(line)) != columns || columns < 9 || (columns-1)%4 != 0 {
+				return nil, fmt.Errorf("Invalid maze line found: %q. Allowed lines of the same length "+
+					"drawing square cells", line)
+			}
+		}
+	}
+
+	return &Dimensions{Length: (columns - 1) / 4, Width: (len(floors[0]) - 1) / 2, Depth: len(floors),
+		Topology: SquareTopology}, nil
+}
+
+// splitRenderedLine splits the provided line into the alternating one character and three
+// characters wide parts that make up a row of the maze, ending with a new line. Crossings
+// are converted back from the way they are drawn.
+func splitRenderedLine(line string) []string {
+	var (
+		row   []string
+		runes = []rune(line)
+	)
+
+	for index := 0; index < len(runes); {
+		width := 1 + 2*(len(row)%2)
+		text := string(runes[index : index+width])
+
+		for content, glyph := range crossingGlyphs {
+			if text == glyph {
+				text = content
+			}
+		}
+
+		row = append(row, text)
+		index += width
+	}
+
+	return append(row, "\n")
+}
+
+// setMarkers sets the starting point and the target from the player and the target markers
+// and links the teleporters with the same digit. The markers are replaced with the stairs
+// hidden beneath them if any and the solution path with empty cells. The path length includes
+// the detours made to collect the keys in order.
+// If the player or the target cannot be found an error is thrown.
+func (config *Dimensions) setMarkers(grid [][]string) error {
+	var (
+		player, target int
+		keyCells       []int
+
+		keys        = map[string]int{}
+		teleporters = map[string][]int{}
+	)
+
+	for cell := 1; cell <= config.getCellsCount(); cell++ {
+		switch content := config.getCellContent(grid, cell); {
+		case content == " "+PlainTheme.Player+" ":
+			player = cell
+
+		case content == " "+PlainTheme.Target+" ":
+			target = cell
+
+		case content == " "+SolutionTheme.Path+" ":
+			config.setCell(grid, cell, emptyCell)
+
+		case isTeleporter(content):
+			teleporters[content] = append(teleporters[content], cell)
+
+		case getCellKey(content) != "":
+			keys[getCellKey(content)] = cell
+		}
+	}
+
+	if player == 0 || target == 0 {
+		return fmt.Errorf("Invalid maze found: the player (%s) or the target (%s) is missing",
+			PlainTheme.Player, PlainTheme.Target)
+	}
+
+	for _, cells := range teleporters {
+		if len(cells) == 2 {
+			config.addTeleporters(grid, cells[0], cells[1], config.getCellContent(grid, cells[0]))
+		}
+	}
+
+	for _, cell := range []int{player, target} {
+		config.setCell(grid, cell, emptyCell)
+		config.restoreStairs(grid, cell)
+	}
+
+	config.StartPosition = config.getCellAddress(player).MiddleCenter
+	config.setTarget(grid, target)
+
+	for _, key := range doorKeys {
+		if cell, ok := keys[key]; ok {
+			keyCells = append(keyCells, cell)
+		}
+	}
+
+	if len(keyCells) > 0 {
+		config.PathLength = config.getKeysPathLength(grid, keyCells)
+	}
+
+	return nil
+}
+
+// restoreStairs adds the stairs hidden by a marker on the provided cell. Stairs always lead to
+// the same cell on the floor above or below, which has the stairs leading back.
+func (config *Dimensions) restoreStairs(grid [][]string, cellNo int) {
+	area := config.Length * config.Width
+
+	if cellNo+area <= config.getCellsCount() && hasStairs(config.getCellContent(grid, cellNo+area), "BELOW") {
+		config.addStairs(grid, cellNo, "ABOVE")
+	}
+
+	if cellNo-area >= 1 && hasStairs(config.getCellContent(grid, cellNo-area), "ABOVE") {
+		config.addStairs(grid, cellNo, "BELOW")
+	}
+}
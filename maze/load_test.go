@@ -0,0 +1,87 @@
+package maze
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestLoadMaze tests the functionality of LoadMaze
+func TestLoadMaze(t *testing.T) {
+	Convey("TestLoadMaze: Given a rendered maze", t, func() {
+		var output bytes.Buffer
+
+		Convey("that draws the shortest path, the markers and the path should be loaded", func() {
+			So(apiMaze().Render(&output, SolutionTheme), ShouldBeNil)
+
+			text := output.String()
+			m, err := LoadMaze(&output)
+
+			So(err, ShouldBeNil)
+			So(m.Cells(), ShouldHaveLength, 9)
+			So(m.Player(), ShouldEqual, 1)
+			So(m.Target(), ShouldEqual, 9)
+			So(m.opts.Intensity, ShouldEqual, 1)
+			So(m.Solve(), ShouldResemble, apiMaze().Solve())
+
+			output.Reset()
+			So(m.Render(&output, SolutionTheme), ShouldBeNil)
+			So(output.String(), ShouldEqual, text)
+		})
+
+		Convey("that has several floors, teleporters and keys, it should be rendered the same once loaded", func() {
+			generated, err := NewMaze(Config{Length: 8, Width: 6, Floors: 2, Intensity: 3, Teleporters: true, Items: true})
+			So(err, ShouldBeNil)
+			So(generated.Render(&output, PlainTheme), ShouldBeNil)
+
+			text := output.String()
+			m, err := LoadMaze(strings.NewReader(text))
+
+			So(err, ShouldBeNil)
+			So(m.Floors(), ShouldEqual, 2)
+			So(m.opts.Intensity, ShouldEqual, 3)
+			So(m.Player(), ShouldEqual, generated.Player())
+			So(m.Target(), ShouldEqual, generated.Target())
+			So(m.config.teleporters, ShouldResemble, generated.config.teleporters)
+			So(m.config.PathLength, ShouldEqual, generated.config.PathLength)
+
+			output.Reset()
+			So(m.Render(&output, PlainTheme), ShouldBeNil)
+			So(output.String(), ShouldEqual, text)
+		})
+
+		Convey("that has crossings, they should be drawn the same once loaded", func() {
+			generated, err := NewMaze(Config{Length: 8, Width: 6, Weave: true})
+			So(err, ShouldBeNil)
+			So(generated.Render(&output, PlainTheme), ShouldBeNil)
+
+			text := output.String()
+			m, err := LoadMaze(strings.NewReader(text))
+
+			So(err, ShouldBeNil)
+
+			// Only the crossings under the player and the target are lost.
+			So(countKinds(m)[Crossing], ShouldBeGreaterThanOrEqualTo, countKinds(generated)[Crossing]-2)
+
+			output.Reset()
+			So(m.Render(&output, PlainTheme), ShouldBeNil)
+			So(output.String(), ShouldEqual, text)
+		})
+
+		Convey("that is missing the target, an error should be thrown", func() {
+			So(apiMaze().Render(&output, Theme{Player: "@"}), ShouldBeNil)
+
+			_, err := LoadMaze(&output)
+
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("that has lines of different lengths, an error should be thrown", func() {
+			_, err := LoadMaze(strings.NewReader("|---|---|\n| @   # |\n|---|---|\n|   |\n|---|---|\n"))
+
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
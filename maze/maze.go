@@ -1,28 +1,58 @@
 package maze
 
-// visitedCells represents the cells whose numbers are mapped to their respective addresses.
-// It is used in creating and navigating through the maze.
-var visitedCells = map[int]cellAddress{}
+import "math"
 
-// Dimensions defines the actual number of cells that make up the maze along the vertical and
-// the horizontal edges. Length represents the number of the cells along the horizontal
-// edge while Width represents the number of the cells along the vertical edge.
+// Dimensions defines the size of the maze together with its starting point and its target.
+// Empty values use square cells on a single floor.
 type Dimensions struct {
-	Length        int
-	Width         int
+	// Length and Width define the number of cells along the horizontal and the vertical edges.
+	Length int
+	Width  int
+
+	// Depth defines the number of floors stacked on top of each other, zero or one means a
+	// single floor.
+	Depth int
+
+	// Topology defines the name of the shape of the cells.
+	Topology string
+
+	// Wrap joins the opposite edges of every floor.
+	Wrap bool
+
+	// StartPosition and FinalPosition define the grid positions of the middle of the starting
+	// point and the target cells.
 	StartPosition []int
 	FinalPosition []int
+
+	// PathLength defines the number of moves on the shortest path from the starting point to
+	// the target, collecting the keys on the way.
+	PathLength int
+
+	// teleporters maps every teleporter cell to its pair.
+	teleporters map[int]int
+
+	// masked holds the cells outside the maze shape.
+	masked map[int]bool
 }
 
 // generateMaze converts the created grid view playing field into a series on paths and walls.
-// The Maze is created such that only a single path can exists between the starting point and
-// and the goal.
-func (config *Dimensions) generateMaze(intensity int) ([][]string, error) {
-	var neighbors []int
+// The Maze is first created such that only a single path can exists between the starting point
+// and the goal. The branching value of the difficulty preset used determines how often the
+// carving resumes from a random earlier cell instead of the newest one. Some of the dead ends
+// are then removed depending on the braid value of the preset, creating multiple routes.
+// The target is finally placed on the cell farthest from the starting point.
+// Cells masked out by the maze shape are never visited and are drawn blank.
+func (config *Dimensions) generateMaze(intensity int, preset Difficulty) ([][]string, error) {
+	var (
+		neighbors []int
+
+		// visitedCells maps the cells already carved to their respective addresses.
+		visitedCells = map[int]cellAddress{}
+	)
 
 	startPos := config.getStartPosition()
 
-	finalPos, cellsPath, currentPos := []int{1, startPos}, []int{startPos}, startPos
+	cellsPath, currentPos := []int{startPos}, startPos
 
 	maze, err := config.createPlayingField(intensity)
 	if err != nil {
@@ -35,15 +65,23 @@ func (config *Dimensions) generateMaze(intensity int) ([][]string, error) {
 
 	cellsPath = append(cellsPath, currentPos)
 
-	for len(visitedCells) < (config.Length * config.Width) {
+	for len(visitedCells) < config.getCellsCount()-len(config.masked) {
+		// Move a random earlier cell to the top of the path so that carving can resume from it.
+		if getRandomNo(100) < int(preset.Branching*100) {
+			index, last := getRandomNo(len(cellsPath)), len(cellsPath)-1
+			cellsPath[index], cellsPath[last] = cellsPath[last], cellsPath[index]
+			currentPos = cellsPath[last]
+		}
+
 		for {
-			neighbors = config.getPresentNeighbors(currentPos)
+			neighbors = config.getPresentNeighbors(currentPos, visitedCells)
 
 			if len(neighbors) > 0 {
 				break
 			}
 
-			cellsPath, currentPos = cellsPath[:len(cellsPath)-1], cellsPath[len(cellsPath)-1]
+			cellsPath = cellsPath[:len(cellsPath)-1]
+			currentPos = cellsPath[len(cellsPath)-1]
 		}
 
 		startPos = neighbors[getRandomNo(len(neighbors))]
@@ -54,53 +92,119 @@ func (config *Dimensions) generateMaze(intensity int) ([][]string, error) {
 			config.createPath(maze[:], currentPos, startPos)
 			cellsPath = append(cellsPath, startPos)
 
-			if len(cellsPath) > finalPos[0] {
-				finalPos[:][1], finalPos[:][0] = startPos, len(cellsPath)
-			}
-
 			currentPos = startPos
 		}
 	}
 
-	config.FinalPosition = config.getCellAddress(finalPos[1]).MiddleCenter
+	config.braidMaze(maze[:], preset.Braid)
 
-	return maze[:], config.optimizeMaze(intensity, maze[:])
+	config.setTarget(maze[:], config.getFarthestCell(maze[:]))
+
+	err = config.optimizeMaze(intensity, maze[:])
+	config.blankMaskedCells(maze[:])
+
+	return maze[:], err
 }
 
 // createPath creates a path on the common wall between the current and the new cell.
 // A path is created by replacing the wall characters with the respective number of blank spaces.
 // Wall characters are defined by the intensity value used while creating the grid view.
+// Cells on different floors are joined by stairs placed on both cells instead.
 func (config *Dimensions) createPath(maze [][]string, currentCellNo, newCellNo int) {
-	addr := config.getCellAddress(currentCellNo)
 	neighbors := config.getCellNeighbors(currentCellNo)
 
-	switch newCellNo {
-	case neighbors.Bottom:
-		maze[addr.BottomCenter[0]][addr.BottomCenter[1]] = "   "
+	switch wall, _ := config.getCommonWall(currentCellNo, newCellNo); {
+	case wall != nil:
+		config.setWall(maze, currentCellNo, newCellNo, " ")
 
-	case neighbors.Left:
-		maze[addr.MiddleLeft[0]][addr.MiddleLeft[1]] = " "
+	case newCellNo == 0:
 
-	case neighbors.Right:
-		maze[addr.MiddleRight[0]][addr.MiddleRight[1]] = " "
+	case newCellNo == neighbors.Above:
+		config.addStairs(maze, currentCellNo, "ABOVE")
+		config.addStairs(maze, newCellNo, "BELOW")
 
-	case neighbors.Top:
-		maze[addr.TopCenter[0]][addr.TopCenter[1]] = "   "
+	case newCellNo == neighbors.Below:
+		config.addStairs(maze, currentCellNo, "BELOW")
+		config.addStairs(maze, newCellNo, "ABOVE")
 	}
 }
 
+// braidMaze removes the provided fraction of the dead ends found in the maze by creating a path
+// to one of their neighboring cells, preferably to another dead end. This creates loops in
+// the maze such that more than one path can exist between the starting point and the goal.
+func (config *Dimensions) braidMaze(maze [][]string, fraction float64) {
+	var (
+		cellNo, index int
+		closedCells   []int
+
+		deadEnds = config.getDeadEnds(maze)
+	)
+
+	for count := int(math.Round(fraction * float64(len(deadEnds)))); count > 0; count-- {
+		index = getRandomNo(len(deadEnds))
+		cellNo = deadEnds[index]
+		deadEnds = append(deadEnds[:index], deadEnds[index+1:]...)
+
+		closedCells = config.getClosedNeighbors(maze, cellNo)
+
+		// The dead end might have been removed while removing another dead end.
+		if len(config.getOpenNeighbors(maze, cellNo)) != 1 || len(closedCells) == 0 {
+			continue
+		}
+
+		for _, neighbor := range closedCells {
+			if len(config.getOpenNeighbors(maze, neighbor)) == 1 {
+				closedCells = []int{neighbor}
+				break
+			}
+		}
+
+		config.createPath(maze, cellNo, closedCells[getRandomNo(len(closedCells))])
+	}
+}
+
+// getDeadEnds returns all the cells in the maze that share a common path with only one other cell.
+func (config *Dimensions) getDeadEnds(maze [][]string) []int {
+	var deadEnds []int
+
+	for cell := 1; cell <= config.getCellsCount(); cell++ {
+		if len(config.getOpenNeighbors(maze, cell)) == 1 {
+			deadEnds = append(deadEnds, cell)
+		}
+	}
+
+	return deadEnds
+}
+
+// getClosedNeighbors returns the neighboring cells that are separated from the provided cell by a wall
+// or that are on the floors above and below without stairs leading to them.
+func (config *Dimensions) getClosedNeighbors(maze [][]string, cellNo int) []int {
+	var (
+		closedCells []int
+
+		openCells = config.getOpenNeighbors(maze, cellNo)
+	)
+
+	for _, neighbor := range config.getNeighborCells(cellNo) {
+		if !isCellFound(openCells, neighbor) {
+			closedCells = append(closedCells, neighbor)
+		}
+	}
+
+	return closedCells
+}
+
 // getPresentNeighbors returns a slice of the neigboring cells associated with the cell number provided.
-// Only neighboring cells with no common paths to others cells that are returned. i.e. Non-Visited Cells.
-func (config *Dimensions) getPresentNeighbors(cellNo int) []int {
+// Only neighboring cells with no common paths to others cells that are returned. i.e. Cells
+// not found among the visited cells provided.
+func (config *Dimensions) getPresentNeighbors(cellNo int, visitedCells map[int]cellAddress) []int {
 	var (
 		ok           bool
 		presentCells []int
-
-		neighbors = config.getCellNeighbors(cellNo)
 	)
 
-	for _, neighbor := range []int{neighbors.Bottom, neighbors.Left, neighbors.Right, neighbors.Top} {
-		if _, ok = visitedCells[neighbor]; !ok && neighbor != 0 {
+	for _, neighbor := range config.getNeighborCells(cellNo) {
+		if _, ok = visitedCells[neighbor]; !ok {
 			presentCells = append(presentCells, neighbor)
 		}
 	}
@@ -109,27 +213,27 @@ func (config *Dimensions) getPresentNeighbors(cellNo int) []int {
 }
 
 // getStartPosition returns the cell which becomes the maze traversal starting position.
-// The starting position can only be a cell along the  maze edges i.e. has less than four
-// neighbors. When getStartPosition is called, all cells are have no common paths to other cells.
+// The starting position can only be a cell along the  maze edges i.e. has less neighbors on
+// its floor than the directions allowed by the topology, four for square cells. The stairs
+// to the other floors are not counted. Any cell can be used once the edges wrap around.
+// When getStartPosition is called, all cells are have no common paths to other cells.
 func (config *Dimensions) getStartPosition() int {
-	var (
-		neighbors  []int
-		randCellNo int
-	)
-
 	for {
-		randCellNo = getRandomNo((config.Length * config.Width) + 1)
+		randCellNo := getRandomNo(config.getCellsCount() + 1)
 
-		neighbors = config.getPresentNeighbors(randCellNo)
+		if randCellNo == 0 || config.masked[randCellNo] {
+			continue
+		}
 
-		if len(neighbors) < 4 && randCellNo != 0 {
+		if config.Wrap || len(config.getFloorNeighbors(randCellNo)) < len(config.getDirections(randCellNo)) {
 			return randCellNo
 		}
 	}
 }
 
 // optimizeMaze replaces some wall characters so as the maze can
-// be more clear and sharp when printed on the terminal.
+// be more clear and sharp when printed on the terminal. Only square cells have
+// wall characters at their corners that can be replaced.
 func (config *Dimensions) optimizeMaze(intensity int, maze [][]string) error {
 	var (
 		addr  cellAddress
@@ -141,7 +245,11 @@ func (config *Dimensions) optimizeMaze(intensity int, maze [][]string) error {
 		panic(err)
 	}
 
-	for cell := 1; cell <= (config.Length * config.Width); cell++ {
+	if _, ok := config.getTopology().(squareGrid); !ok {
+		return nil
+	}
+
+	for cell := 1; cell <= config.getCellsCount(); cell++ {
 		addr = config.getCellAddress(cell)
 
 		config.replaceChar(addr.BottomRight, chars[2], maze)
@@ -152,19 +260,21 @@ func (config *Dimensions) optimizeMaze(intensity int, maze [][]string) error {
 }
 
 // replaceChar switches left and right wall character with a top and bottom wall character.
+// Only the points on the same floor as the given point are considered.
 func (config *Dimensions) replaceChar(point []int, replChar string, maze [][]string) {
 
 	elemTop, elemBottom := "", ""
 	lenTop, lenBottom := false, false
+	row := point[0] % config.getFloorRows()
 
 	// checks if the top point in relation to the given point can be calculated
-	if (point[0] - 1) > 0 {
+	if (row - 1) > 0 {
 		elemTop = maze[point[0]-1][point[1]]
 		lenTop = true
 	}
 
 	// checks if the bottom point in relation to the given point can be calculated
-	if (point[0] + 1) <= (config.Width * 2) {
+	if (row + 1) <= (config.Width * 2) {
 		elemBottom = maze[point[0]+1][point[1]]
 		lenBottom = true
 	}
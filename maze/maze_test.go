@@ -29,7 +29,7 @@ func TestGenerateMaze(t *testing.T) {
 
 	Convey("Given the correct intensity value", t, func() {
 		Convey("If an incorrect intensity value is used an error should be returned ", func() {
-			data, err := val.generateMaze(-1)
+			data, err := val.generateMaze(-1, presets["normal"])
 
 			So(data, ShouldBeEmpty)
 			So(val.StartPosition, ShouldBeEmpty)
@@ -40,7 +40,7 @@ func TestGenerateMaze(t *testing.T) {
 		})
 
 		Convey("The maze should be generated without an error", func() {
-			data, err := val.generateMaze(1)
+			data, err := val.generateMaze(1, presets["normal"])
 
 			So(data, ShouldNotBeEmpty)
 			So(val.StartPosition, ShouldNotBeEmpty)
@@ -116,7 +116,7 @@ func TestGetPresentNeighbors(t *testing.T) {
 	Convey("Given a cell number ", t, func() {
 		Convey("The return slice of neighbors should be same as the expected slice", func() {
 			for cell, otherCells := range testData {
-				neighbors = val.getPresentNeighbors(cell)
+				neighbors = val.getPresentNeighbors(cell, nil)
 
 				for _, value := range neighbors {
 					So(otherCells, ShouldContain, value)
@@ -136,7 +136,7 @@ func TestGetStartPosition(t *testing.T) {
 	Convey("The start position returned should have less than four neighbors ", t, func() {
 		var (
 			cellNo    = val.getStartPosition()
-			neighbors = val.getPresentNeighbors(cellNo)
+			neighbors = val.getPresentNeighbors(cellNo, nil)
 		)
 
 		So(len(neighbors), ShouldBeLessThan, 4)
@@ -145,3 +145,57 @@ func TestGetStartPosition(t *testing.T) {
 		log.Printf("Neighbors : %v \n", neighbors)
 	})
 }
+
+// TestBraidMaze tests the functionality of braidMaze
+func TestBraidMaze(t *testing.T) {
+	var testFunc = func(fraction float64) (int, int) {
+		val := &Dimensions{Length: 15, Width: 10}
+		data, err := val.generateMaze(1, Difficulty{})
+
+		So(err, ShouldBeNil)
+
+		before := len(val.getDeadEnds(data))
+		val.braidMaze(data, fraction)
+
+		return before, len(val.getDeadEnds(data))
+	}
+
+	Convey("TestBraidMaze: Given a perfect maze and the fraction of dead ends to remove", t, func() {
+		Convey("that is zero, no dead end should be removed", func() {
+			before, after := testFunc(0)
+			So(after, ShouldEqual, before)
+		})
+
+		Convey("that is half, at least half of the dead ends should be removed", func() {
+			before, after := testFunc(0.5)
+			So(after, ShouldBeLessThanOrEqualTo, before-before/2)
+		})
+
+		Convey("that is one, all the dead ends should be removed", func() {
+			_, after := testFunc(1)
+			So(after, ShouldEqual, 0)
+		})
+	})
+}
+
+// TestGetDeadEnds tests the functionality of getDeadEnds
+func TestGetDeadEnds(t *testing.T) {
+	Convey("TestGetDeadEnds: Given a maze, only cells with a single common path should be returned", t, func() {
+		val := &Dimensions{Length: 3, Width: 3}
+
+		So(val.getDeadEnds(testMaze), ShouldResemble, []int{1, 3, 7, 8})
+	})
+}
+
+// TestGetClosedNeighbors tests the functionality of getClosedNeighbors
+func TestGetClosedNeighbors(t *testing.T) {
+	Convey("TestGetClosedNeighbors: Given a cell, only neighbors separated by a wall should be returned", t, func() {
+		val := &Dimensions{Length: 3, Width: 3}
+
+		for cell, expected := range map[int][]int{
+			1: {4}, 5: {8}, 6: nil, 7: {8}, 8: {7, 5},
+		} {
+			So(val.getClosedNeighbors(testMaze, cell), ShouldResemble, expected)
+		}
+	})
+}
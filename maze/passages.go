@@ -0,0 +1,302 @@
+package maze
+
+import "strconv"
+
+// oneWayGates defines the wall characters of the one-way gates mapped to the only
+// direction in which they can be crossed.
+var oneWayGates = map[string]string{
+	"<":   "LEFT",
+	">":   "RIGHT",
+	"-^-": "UP",
+	"-v-": "DOWN",
+}
+
+// maxTeleporters defines the maximum number of teleporter pairs that can be placed in a maze.
+// Each pair is displayed using its own digit.
+const maxTeleporters = 9
+
+// isWallPassable checks if the provided wall can be crossed in the provided direction.
+// A wall can be crossed if it is a path or a one-way gate facing the provided direction.
+func isWallPassable(wall, direction string) bool {
+	return isSpaceFound(wall) || oneWayGates[wall] == direction
+}
+
+// isTeleporter checks if the provided cell content is a teleporter digit.
+func isTeleporter(cell string) bool {
+	return len(cell) == 3 && cell[0] == ' ' && cell[2] == ' ' && cell[1] >= '1' && cell[1] <= '9'
+}
+
+// getTeleporterExit returns the cell where the player ends up after moving into the provided
+// cell. If the cell is a teleporter its pair is returned, otherwise the same cell is returned.
+func (config *Dimensions) getTeleporterExit(cellNo int) int {
+	if exit, ok := config.teleporters[cellNo]; ok {
+		return exit
+	}
+	return cellNo
+}
+
+// placePassages places the provided number of teleporter pairs and one-way gates in the
+// maze. Each teleporter pair is placed on two distant cells while the one-way gates replace
+// random paths between cells. A teleporter pair or a gate is only kept if the target can
+// still be reached from every cell that the player can get to. The open neighbors of every
+// cell are found once and only updated around the cells changed by each passage.
+func (config *Dimensions) placePassages(maze [][]string, teleporters, gates int) {
+	var (
+		area  = config.getCellsCount()
+		start = config.getCellNumber(config.StartPosition)
+		graph = config.getPassageGraph(maze)
+	)
+
+	if teleporters > maxTeleporters {
+		teleporters = maxTeleporters
+	}
+
+	for pair := 1; pair <= teleporters; pair++ {
+		reachable := map[int]bool{}
+		for cell := range getGraphDistances(graph, start) {
+			reachable[cell] = true
+		}
+
+		cells := config.getFreeCells(maze, reachable)
+		if len(cells) < 2 {
+			break
+		}
+
+		entry := cells[getRandomNo(len(cells))]
+		exit := getDistantCell(getGraphDistances(graph, entry), entry, cells)
+
+		config.addTeleporters(maze, entry, exit, " "+strconv.Itoa(pair)+" ")
+		config.updatePassageGraph(maze, graph, entry, exit)
+
+		if !config.isGraphTargetAlwaysReachable(graph) {
+			config.removeTeleporters(maze, entry, exit)
+			config.updatePassageGraph(maze, graph, entry, exit)
+		}
+	}
+
+	for ; gates > 0; gates-- {
+		cellNo := 1 + getRandomNo(area)
+		openCells := graph[cellNo]
+
+		if len(openCells) == 0 {
+			continue
+		}
+
+		neighbor := openCells[getRandomNo(len(openCells))]
+		wall, direction := config.getCommonWall(cellNo, neighbor)
+
+		if wall == nil || !isSpaceFound(maze[wall[0]][wall[1]]) {
+			continue
+		}
+
+		for gate, gateDirection := range oneWayGates {
+			for _, point := range config.getCommonWalls(cellNo, neighbor) {
+				if gateDirection == direction {
+					maze[point[0]][point[1]] = gate
+				}
+			}
+		}
+
+		config.updatePassageGraph(maze, graph, cellNo, neighbor)
+
+		if !config.isGraphTargetAlwaysReachable(graph) {
+			config.createPath(maze, cellNo, neighbor)
+			config.updatePassageGraph(maze, graph, cellNo, neighbor)
+		}
+	}
+}
+
+// getPassageGraph returns the open neighbors of every cell of the maze, indexed by the cell.
+func (config *Dimensions) getPassageGraph(maze [][]string) [][]int {
+	graph := make([][]int, config.getCellsCount()+1)
+
+	for cell := 1; cell < len(graph); cell++ {
+		graph[cell] = config.getOpenNeighbors(maze, cell)
+	}
+
+	return graph
+}
+
+// updatePassageGraph finds again the open neighbors of the provided cells and of the cells
+// that led to them before the maze was changed. These are the only cells whose moves change
+// when a teleporter pair or a gate is added or removed.
+func (config *Dimensions) updatePassageGraph(maze [][]string, graph [][]int, cells ...int) {
+	changed := append([]int{}, cells...)
+
+	for cell, openCells := range graph {
+		for _, next := range openCells {
+			if isCellFound(cells, next) && !isCellFound(changed, cell) {
+				changed = append(changed, cell)
+			}
+		}
+	}
+
+	for _, cell := range changed {
+		graph[cell] = config.getOpenNeighbors(maze, cell)
+	}
+}
+
+// walkGraph returns the cells reached from the provided cell using the open neighbors of the
+// provided graph, indexed by the cell.
+func walkGraph(graph [][]int, startCell int) []bool {
+	var (
+		current int
+
+		queue   = []int{startCell}
+		reached = make([]bool, len(graph))
+	)
+
+	reached[startCell] = true
+
+	for len(queue) > 0 {
+		current, queue = queue[0], queue[1:]
+
+		for _, neighbor := range graph[current] {
+			if !reached[neighbor] {
+				reached[neighbor] = true
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	return reached
+}
+
+// getGraphDistances returns the number of moves from the provided cell to every cell that
+// can be reached from it using the open neighbors of the provided graph.
+func getGraphDistances(graph [][]int, startCell int) map[int]int {
+	var (
+		current int
+
+		queue     = []int{startCell}
+		distances = map[int]int{startCell: 0}
+	)
+
+	for len(queue) > 0 {
+		current, queue = queue[0], queue[1:]
+
+		for _, neighbor := range graph[current] {
+			if _, ok := distances[neighbor]; !ok {
+				distances[neighbor] = distances[current] + 1
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	return distances
+}
+
+// getCommonWall returns the position of the common wall between the provided cell and its
+// neighbor together with the direction moved when crossing it from the cell to the neighbor.
+// A nil position is returned if the two cells are not neighbors on the same floor.
+func (config *Dimensions) getCommonWall(cellNo, neighbor int) ([]int, string) {
+	neighbors := config.getCellNeighbors(cellNo)
+
+	for _, direction := range neighborDirections {
+		if neighbor != 0 && getNeighbor(neighbors, direction) == neighbor {
+			return getWall(config.getCellAddress(cellNo), direction), direction
+		}
+	}
+
+	return nil, ""
+}
+
+// getCommonWalls returns the positions of the walls between the provided cell and its
+// neighbor. Cells joined across the wrapped edges of the maze are separated by a wall on
+// each edge while the other cells share a single wall. Nil is returned if the cells do not
+// share a wall.
+func (config *Dimensions) getCommonWalls(cellNo, neighbor int) [][]int {
+	wall, _ := config.getCommonWall(cellNo, neighbor)
+	if wall == nil {
+		return nil
+	}
+
+	if back, _ := config.getCommonWall(neighbor, cellNo); back != nil && (back[0] != wall[0] || back[1] != wall[1]) {
+		return [][]int{wall, back}
+	}
+
+	return [][]int{wall}
+}
+
+// getDistantCell returns a random cell among the provided cells that is at least half the
+// distance to the farthest of them away from the provided cell, using the provided distances
+// from the cell.
+func getDistantCell(distances map[int]int, cellNo int, cells []int) int {
+	var (
+		distant []int
+		maxDist int
+	)
+
+	for _, cell := range cells {
+		if distances[cell] > maxDist {
+			maxDist = distances[cell]
+		}
+	}
+
+	for _, cell := range cells {
+		if cell != cellNo && distances[cell] >= getCeiledDivisor(maxDist, 2) {
+			distant = append(distant, cell)
+		}
+	}
+
+	if len(distant) == 0 {
+		return cellNo
+	}
+
+	return distant[getRandomNo(len(distant))]
+}
+
+// addTeleporters links the two provided cells as a teleporter pair displayed using the
+// provided content.
+func (config *Dimensions) addTeleporters(maze [][]string, entry, exit int, content string) {
+	if entry == exit {
+		return
+	}
+
+	if config.teleporters == nil {
+		config.teleporters = map[int]int{}
+	}
+
+	config.teleporters[entry], config.teleporters[exit] = exit, entry
+
+	config.setCell(maze, entry, content)
+	config.setCell(maze, exit, content)
+}
+
+// removeTeleporters unlinks the two provided cells and clears their content.
+func (config *Dimensions) removeTeleporters(maze [][]string, entry, exit int) {
+	delete(config.teleporters, entry)
+	delete(config.teleporters, exit)
+
+	config.setCell(maze, entry, emptyCell)
+	config.setCell(maze, exit, emptyCell)
+}
+
+// isTargetAlwaysReachable checks if the target can be reached from every cell that can be
+// reached from the current player position. This ensures that the one-way gates and the
+// teleporters can never trap the player away from the target.
+func (config *Dimensions) isTargetAlwaysReachable(maze [][]string) bool {
+	return config.isGraphTargetAlwaysReachable(config.getPassageGraph(maze))
+}
+
+// isGraphTargetAlwaysReachable checks if the target can be reached from every cell that can
+// be reached from the current player position using the open neighbors of the provided graph.
+func (config *Dimensions) isGraphTargetAlwaysReachable(graph [][]int) bool {
+	predecessors := make([][]int, len(graph))
+
+	for cell, openCells := range graph {
+		for _, neighbor := range openCells {
+			predecessors[neighbor] = append(predecessors[neighbor], cell)
+		}
+	}
+
+	reaching := walkGraph(predecessors, config.getCellNumber(config.FinalPosition))
+
+	for cell, reached := range walkGraph(graph, config.getCellNumber(config.StartPosition)) {
+		if reached && !reaching[cell] {
+			return false
+		}
+	}
+
+	return true
+}
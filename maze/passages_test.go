@@ -0,0 +1,221 @@
+package maze
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestIsWallPassable tests the functionality of isWallPassable
+func TestIsWallPassable(t *testing.T) {
+	Convey("TestIsWallPassable: Given a wall and a direction", t, func() {
+		Convey("that is a path, it should be passable in all directions", func() {
+			for _, direction := range neighborDirections {
+				So(isWallPassable(" ", direction), ShouldBeTrue)
+				So(isWallPassable("   ", direction), ShouldBeTrue)
+			}
+		})
+
+		Convey("that is a one-way gate, it should only be passable in its direction", func() {
+			for gate, gateDirection := range oneWayGates {
+				for _, direction := range neighborDirections {
+					So(isWallPassable(gate, direction), ShouldEqual, direction == gateDirection)
+				}
+			}
+		})
+
+		Convey("that is a wall or a door, it should not be passable", func() {
+			for _, direction := range neighborDirections {
+				So(isWallPassable("|", direction), ShouldBeFalse)
+				So(isWallPassable("AAA", direction), ShouldBeFalse)
+			}
+		})
+	})
+}
+
+// TestIsTeleporter tests the functionality of isTeleporter
+func TestIsTeleporter(t *testing.T) {
+	Convey("TestIsTeleporter: Given the cell content, only teleporter digits should be detected", t, func() {
+		So(isTeleporter(" 1 "), ShouldBeTrue)
+		So(isTeleporter(" 9 "), ShouldBeTrue)
+		So(isTeleporter(" 0 "), ShouldBeFalse)
+		So(isTeleporter(" a "), ShouldBeFalse)
+		So(isTeleporter(coinItem), ShouldBeFalse)
+		So(isTeleporter(emptyCell), ShouldBeFalse)
+	})
+}
+
+// TestGetCommonWall tests the functionality of getCommonWall
+func TestGetCommonWall(t *testing.T) {
+	Convey("TestGetCommonWall: Given a cell and another cell", t, func() {
+		val := &Dimensions{Length: 3, Width: 3}
+
+		Convey("that is its neighbor, the common wall and the direction should be returned", func() {
+			for neighbor, expected := range map[int]struct {
+				wall      []int
+				direction string
+			}{
+				2: {[]int{2, 3}, "UP"}, 4: {[]int{3, 2}, "LEFT"},
+				6: {[]int{3, 4}, "RIGHT"}, 8: {[]int{4, 3}, "DOWN"},
+			} {
+				wall, direction := val.getCommonWall(5, neighbor)
+
+				So(wall, ShouldResemble, expected.wall)
+				So(direction, ShouldEqual, expected.direction)
+			}
+		})
+
+		Convey("that is not its neighbor, a nil wall should be returned", func() {
+			wall, direction := val.getCommonWall(5, 9)
+
+			So(wall, ShouldBeNil)
+			So(direction, ShouldBeEmpty)
+		})
+	})
+}
+
+// TestGetCommonWalls tests the functionality of getCommonWalls
+func TestGetCommonWalls(t *testing.T) {
+	Convey("TestGetCommonWalls: Given a cell and another cell", t, func() {
+		val := &Dimensions{Length: 3, Width: 3, Wrap: true}
+
+		Convey("that is joined to it inside the maze, the single shared wall should be returned", func() {
+			So(val.getCommonWalls(5, 6), ShouldResemble, [][]int{{3, 4}})
+		})
+
+		Convey("that is joined to it across the wrapped edges, the walls on both edges should be returned", func() {
+			So(val.getCommonWalls(4, 6), ShouldResemble, [][]int{{3, 0}, {3, 6}})
+			So(val.getCommonWalls(2, 8), ShouldResemble, [][]int{{0, 3}, {6, 3}})
+		})
+
+		Convey("that is not its neighbor, nil should be returned", func() {
+			So(val.getCommonWalls(5, 9), ShouldBeNil)
+		})
+	})
+}
+
+// TestGetPassableNeighbors tests the functionality of getPassableNeighbors
+func TestGetPassableNeighbors(t *testing.T) {
+	Convey("TestGetPassableNeighbors: Given a maze with passages", t, func() {
+		val := &Dimensions{Length: 3, Width: 3}
+		data := copyMaze(testMaze)
+
+		Convey("a one-way gate should only be crossed in its direction", func() {
+			data[3][4] = ">"
+
+			So(val.getOpenNeighbors(data, 5), ShouldContain, 6)
+			So(val.getOpenNeighbors(data, 6), ShouldNotContain, 5)
+		})
+
+		Convey("a neighboring teleporter should be replaced by its pair", func() {
+			val.addTeleporters(data, 2, 9, " 1 ")
+
+			So(val.getOpenNeighbors(data, 1), ShouldResemble, []int{9})
+			So(val.getOpenNeighbors(data, 5), ShouldContain, 9)
+			So(val.getOpenNeighbors(data, 5), ShouldNotContain, 2)
+
+			val.removeTeleporters(data, 2, 9)
+
+			So(val.getOpenNeighbors(data, 1), ShouldResemble, []int{2})
+			So(data[1][3], ShouldEqual, emptyCell)
+		})
+
+		Convey("a door should only be crossed with its key", func() {
+			val.setWall(data, 5, 6, "A")
+
+			So(val.getPassableNeighbors(data, 5, nil), ShouldNotContain, 6)
+			So(val.getPassableNeighbors(data, 5, map[string]bool{"a": true}), ShouldContain, 6)
+		})
+	})
+}
+
+// TestIsTargetAlwaysReachable tests the functionality of isTargetAlwaysReachable
+func TestIsTargetAlwaysReachable(t *testing.T) {
+	Convey("TestIsTargetAlwaysReachable: Given a maze with a one-way gate", t, func() {
+		val := &Dimensions{Length: 3, Width: 3}
+		val.StartPosition = val.getCellAddress(1).MiddleCenter
+		val.FinalPosition = val.getCellAddress(9).MiddleCenter
+		data := copyMaze(testMaze)
+
+		Convey("facing the target, true should be returned", func() {
+			data[3][4] = ">"
+			So(val.isTargetAlwaysReachable(data), ShouldBeTrue)
+		})
+
+		Convey("facing away from the target, false should be returned", func() {
+			data[3][4] = "<"
+			So(val.isTargetAlwaysReachable(data), ShouldBeFalse)
+		})
+
+		Convey("leading into a dead end, false should be returned", func() {
+			data[4][1] = "-v-"
+			So(val.isTargetAlwaysReachable(data), ShouldBeFalse)
+		})
+	})
+}
+
+// TestPlacePassages tests the functionality of placePassages
+func TestPlacePassages(t *testing.T) {
+	Convey("TestPlacePassages: Given a generated maze", t, func() {
+		val := &Dimensions{Length: 20, Width: 16}
+		data, err := val.generateMaze(1, presets["normal"])
+
+		So(err, ShouldBeNil)
+
+		val.placePassages(data, 3, 20)
+
+		Convey("the teleporters should be placed in pairs", func() {
+			for entry, exit := range val.teleporters {
+				So(val.teleporters[exit], ShouldEqual, entry)
+			}
+		})
+
+		Convey("the target should be reachable from every reachable cell", func() {
+			So(val.isTargetAlwaysReachable(data), ShouldBeTrue)
+			So(val.solveMaze(data, val.getCellNumber(val.StartPosition),
+				val.getCellNumber(val.FinalPosition)), ShouldNotBeEmpty)
+		})
+	})
+}
+
+// TestUpdatePassageGraph tests the functionality of updatePassageGraph
+func TestUpdatePassageGraph(t *testing.T) {
+	Convey("TestUpdatePassageGraph: Given the open neighbors of a generated maze", t, func() {
+		val := &Dimensions{Length: 12, Width: 8}
+		data, err := val.generateMaze(1, presets["normal"])
+
+		So(err, ShouldBeNil)
+
+		graph := val.getPassageGraph(data)
+
+		Convey("updated around a new teleporter pair, they should match the whole maze", func() {
+			val.addTeleporters(data, 5, 90, " 1 ")
+			val.updatePassageGraph(data, graph, 5, 90)
+
+			So(graph, ShouldResemble, val.getPassageGraph(data))
+
+			val.removeTeleporters(data, 5, 90)
+			val.updatePassageGraph(data, graph, 5, 90)
+
+			So(graph, ShouldResemble, val.getPassageGraph(data))
+		})
+
+		Convey("updated around a new one-way gate, they should match the whole maze", func() {
+			neighbor := graph[40][0]
+			wall, direction := val.getCommonWall(40, neighbor)
+
+			So(wall, ShouldNotBeNil)
+
+			for gate, gateDirection := range oneWayGates {
+				if gateDirection == direction {
+					data[wall[0]][wall[1]] = gate
+				}
+			}
+
+			val.updatePassageGraph(data, graph, 40, neighbor)
+
+			So(graph, ShouldResemble, val.getPassageGraph(data))
+			So(isCellFound(graph[neighbor], 40), ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,47 @@
+package maze
+
+// playerMovement calculates the actual player position
+// depending on the direction provided. One-way gates can only be crossed in their
+// direction and moving into a teleporter moves the player to its pair. The player goes
+// straight through the tunnel under a bridge but cannot turn into it from the bridge.
+// Moving ABOVE or BELOW takes the player to the next floor if the current cell has stairs.
+// Hexagonal cells have no neighbors on their left and right, moving LEFT or RIGHT takes the
+// player to the upper side neighbor or the lower one instead, whichever can be reached.
+func (config *Dimensions) playerMovement(data [][]string, direction string) {
+	var (
+		next int
+
+		cellNo    = config.getCellNumber(config.StartPosition)
+		addr      = config.getCellAddress(cellNo)
+		neighbors = config.getCellNeighbors(cellNo)
+		content   = config.getCellContent(data, cellNo)
+	)
+
+	if isTunnel(content, direction) {
+		return
+	}
+
+	for _, move := range append([]string{direction}, sideMoves[direction]...) {
+		wall := getWall(addr, move)
+
+		if getNeighbor(neighbors, move) != 0 && wall != nil && isWallPassable(data[wall[0]][wall[1]], move) {
+			next, direction = getNeighbor(neighbors, move), move
+			break
+		}
+	}
+
+	if hasStairs(content, direction) {
+		next = getNeighbor(neighbors, direction)
+	}
+
+	if next == 0 {
+		return
+	}
+
+	// Passing under a bridge takes the player to the cell beyond it.
+	if isTunnel(config.getCellContent(data, next), direction) {
+		next = getNeighbor(config.getCellNeighbors(next), direction)
+	}
+
+	config.StartPosition = config.getCellAddress(config.getTeleporterExit(next)).MiddleCenter
+}
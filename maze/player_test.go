@@ -0,0 +1,148 @@
+package maze
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestPlayerMovement tests the functionality of playerMovement
+func TestPlayerMovement(t *testing.T) {
+	data := [][]string{
+		{"|", "---", "|", "---", "|", "---", "|"},
+		{"|", " A ", " ", "   ", "|", "   ", "|"},
+		{"|", "   ", "|", "   ", "|", "---", "|"},
+		{"|", "   ", " ", " B ", " ", "   ", "|"},
+		{"|", "---", "|", "   ", "|", "---", "|"},
+		{"|", "   ", "|", "   ", "|", "   ", "|"},
+		{"|", "---", "|", "---", "|", "---", "|"},
+	}
+
+	Convey("TestPlayerMovement: Given the grid view and the current player position", t, func() {
+		var d = Dimensions{Length: 3, Width: 3}
+
+		Convey("is at the middle the player should be able to move to all directions"+
+			"position exists for the direction provided", func() {
+			for direction, output := range map[string][]int{
+				"LEFT": {3, 1}, "RIGHT": {3, 5},
+				"DOWN": {5, 3}, "UP": {1, 3}} {
+
+				d.StartPosition = []int{3, 3}
+
+				d.playerMovement(data, direction)
+
+				So(output[0], ShouldEqual, d.StartPosition[0])
+				So(output[1], ShouldEqual, d.StartPosition[1])
+			}
+
+			Convey("is at a corner, the player should only be able to move to directions with spaces", func() {
+				for direction, output := range map[string][]int{
+					"LEFT": {1, 1}, "RIGHT": {1, 3},
+					"DOWN": {3, 1}, "UP": {1, 1}} {
+
+					d.StartPosition = []int{1, 1}
+
+					d.playerMovement(data, direction)
+
+					So(output[0], ShouldEqual, d.StartPosition[0])
+					So(output[1], ShouldEqual, d.StartPosition[1])
+				}
+			})
+		})
+	})
+}
+
+// TestPlayerMovementPassages tests the functionality of playerMovement with passages
+func TestPlayerMovementPassages(t *testing.T) {
+	Convey("TestPlayerMovementPassages: Given a maze with passages", t, func() {
+		var (
+			d    = Dimensions{Length: 3, Width: 3}
+			data = copyMaze(testMaze)
+		)
+
+		Convey("a one-way gate should only be crossed in its direction", func() {
+			data[3][4] = ">"
+
+			d.StartPosition = []int{3, 3}
+			d.playerMovement(data, "RIGHT")
+			So(d.StartPosition, ShouldResemble, []int{3, 5})
+
+			d.playerMovement(data, "LEFT")
+			So(d.StartPosition, ShouldResemble, []int{3, 5})
+		})
+
+		Convey("moving into a teleporter should move the player to its pair", func() {
+			d.addTeleporters(data, 2, 9, " 1 ")
+
+			d.StartPosition = []int{1, 1}
+			d.playerMovement(data, "RIGHT")
+			So(d.StartPosition, ShouldResemble, []int{5, 5})
+		})
+	})
+}
+
+// TestPlayerMovementWeave tests the functionality of playerMovement in weave mazes
+func TestPlayerMovementWeave(t *testing.T) {
+	Convey("TestPlayerMovementWeave: Given a maze with a crossing", t, func() {
+		var (
+			d    = Dimensions{Length: 3, Width: 3, StartPosition: []int{1, 1}}
+			data = copyMaze(crossingMaze)
+		)
+
+		d.weaveMaze(data, 1)
+
+		Convey("moving into the tunnel should take the player straight through it", func() {
+			d.StartPosition = []int{1, 3}
+			d.playerMovement(data, "DOWN")
+			So(d.StartPosition, ShouldResemble, []int{5, 3})
+
+			d.playerMovement(data, "UP")
+			So(d.StartPosition, ShouldResemble, []int{1, 3})
+		})
+
+		Convey("moving onto the bridge should be allowed but turning into the tunnel should not", func() {
+			d.StartPosition = []int{3, 1}
+			d.playerMovement(data, "RIGHT")
+			So(d.StartPosition, ShouldResemble, []int{3, 3})
+
+			for _, direction := range []string{"UP", "DOWN"} {
+				d.playerMovement(data, direction)
+				So(d.StartPosition, ShouldResemble, []int{3, 3})
+			}
+		})
+	})
+}
+
+// TestPlayerMovementFloors tests the functionality of playerMovement in multi-floor mazes
+func TestPlayerMovementFloors(t *testing.T) {
+	Convey("TestPlayerMovementFloors: Given a maze with two floors joined by stairs", t, func() {
+		d := Dimensions{Length: 2, Width: 2, Depth: 2}
+		data, err := d.createPlayingField(1)
+
+		So(err, ShouldBeNil)
+
+		d.createPath(data, 1, 2)
+		d.createPath(data, 2, 6)
+		d.createPath(data, 6, 8)
+		d.StartPosition = d.getCellAddress(1).MiddleCenter
+
+		Convey("the player should only change floors on the stairs", func() {
+			d.playerMovement(data, "ABOVE")
+			So(d.getCellNumber(d.StartPosition), ShouldEqual, 1)
+
+			d.playerMovement(data, "RIGHT")
+			d.playerMovement(data, "ABOVE")
+			So(d.getCellNumber(d.StartPosition), ShouldEqual, 6)
+
+			d.playerMovement(data, "DOWN")
+			So(d.getCellNumber(d.StartPosition), ShouldEqual, 8)
+
+			d.playerMovement(data, "DOWN")
+			So(d.getCellNumber(d.StartPosition), ShouldEqual, 8)
+
+			d.playerMovement(data, "UP")
+			d.playerMovement(data, "BELOW")
+			So(d.getCellNumber(d.StartPosition), ShouldEqual, 2)
+		})
+	})
+}
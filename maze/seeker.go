@@ -0,0 +1,280 @@
+package maze
+
+import "fmt"
+
+const (
+	// WallFollower seeker keeps its right hand on the maze wall until it locates the target.
+	// It may never locate a target that is placed on a loop away from the maze walls.
+	WallFollower = "wallfollower"
+
+	// Tremaux seeker marks every passage it uses and never uses a passage marked twice.
+	// It always locates the target but might explore most of the maze before doing so.
+	Tremaux = "tremaux"
+
+	// PartialBFS seeker only knows the parts of the maze it has seen along the straight
+	// corridors around it. It follows the shortest path to the target once the target has
+	// been seen, otherwise it explores the closest cell it has not visited yet.
+	PartialBFS = "bfs"
+
+	// RandomWalk seeker moves to a random neighboring cell and only turns back at dead ends.
+	RandomWalk = "random"
+)
+
+// seeker defines a computer controlled player that races the human player to the target.
+// Seekers cannot collect keys and treat doors as walls, so they never locate a locked target.
+// Position and previous are the current and the previous cells of the seeker. Heading is the
+// direction of the last move made by the wall follower, marks holds the number of times each passage
+// has been used while seen holds the cells whose paths are known to the seeker.
+type seeker struct {
+	strategy string
+	position int
+	previous int
+	heading  string
+	marks    map[[2]int]int
+	seen     map[int]bool
+}
+
+// newSeeker creates a computer seeker using the provided strategy at the provided cell.
+// If an invalid strategy is used an error is thrown.
+func newSeeker(strategy string, cellNo int) (*seeker, error) {
+	switch strategy {
+	case WallFollower, Tremaux, PartialBFS, RandomWalk:
+		return &seeker{
+			strategy: strategy,
+			position: cellNo,
+			marks:    map[[2]int]int{},
+			seen:     map[int]bool{},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("Invalid seeker strategy found: %s. Allowed %s, %s, %s and %s",
+		strategy, WallFollower, Tremaux, PartialBFS, RandomWalk)
+}
+
+// move makes a single move towards the target depending on the seeker strategy.
+// The seeker stays in place once the target has been located.
+func (s *seeker) move(config *Dimensions, maze [][]string) {
+	var next int
+
+	if s.position == config.getCellNumber(config.FinalPosition) {
+		return
+	}
+
+	switch s.strategy {
+	case WallFollower:
+		next = s.followWall(config, maze)
+
+	case Tremaux:
+		next = s.tremaux(config, maze)
+
+	case PartialBFS:
+		next = s.exploreSeen(config, maze)
+
+	default:
+		next = s.walkRandomly(config, maze)
+	}
+
+	if next != 0 {
+		s.previous, s.position = s.position, next
+	}
+}
+
+// getNeighbor returns the neighbor of the provided cell in the provided direction.
+func getNeighbor(neighbors cellNeighbors, direction string) int {
+	switch direction {
+	case "UP":
+		return neighbors.Top
+	case "RIGHT":
+		return neighbors.Right
+	case "DOWN":
+		return neighbors.Bottom
+	case "LEFT":
+		return neighbors.Left
+	case "ABOVE":
+		return neighbors.Above
+	case "BELOW":
+		return neighbors.Below
+	case "UPLEFT":
+		return neighbors.UpperLeft
+	case "UPRIGHT":
+		return neighbors.UpperRight
+	case "DOWNLEFT":
+		return neighbors.LowerLeft
+	case "DOWNRIGHT":
+		return neighbors.LowerRight
+	}
+
+	return 0
+}
+
+// followWall returns the next cell using the right hand rule. The sides of the cell are tried
+// counterclockwise starting next to the side the seeker came through, i.e. from the sharpest
+// right turn to the sharpest left turn, and the seeker finally turns back. Stairs that do not
+// lead back to the previous cell are taken before turning back.
+func (s *seeker) followWall(config *Dimensions, maze [][]string) int {
+	var (
+		back int
+
+		directions = config.getDirections(s.position)
+		neighbors  = config.getCellNeighbors(s.position)
+		openCells  = config.getOpenNeighbors(maze, s.position)
+	)
+
+	for index, direction := range directions {
+		if direction == oppositeDirections[s.heading] {
+			back = index
+		}
+	}
+
+	for turn := 1; turn <= len(directions); turn++ {
+		direction := directions[(back-turn+len(directions))%len(directions)]
+
+		for _, floor := range floorDirections {
+			next := getNeighbor(neighbors, floor)
+
+			if turn == len(directions) && next != 0 && next != s.previous && isCellFound(openCells, next) {
+				return next
+			}
+		}
+
+		if next := getNeighbor(neighbors, direction); next != 0 && isCellFound(openCells, next) {
+			s.heading = direction
+			return next
+		}
+	}
+
+	// The only way out of a dead end is the stairs used to get to it.
+	for _, direction := range floorDirections {
+		if next := getNeighbor(neighbors, direction); next != 0 && isCellFound(openCells, next) {
+			return next
+		}
+	}
+
+	return 0
+}
+
+// getPassage returns the key used to mark the passage between the two provided cells.
+func getPassage(cellA, cellB int) [2]int {
+	if cellA > cellB {
+		return [2]int{cellB, cellA}
+	}
+	return [2]int{cellA, cellB}
+}
+
+// tremaux returns the next cell using the Trémaux algorithm. When the seeker arrives at
+// an already visited cell through a new passage it turns back, otherwise it picks the
+// passage with the least marks. Passages marked twice are never used again.
+func (s *seeker) tremaux(config *Dimensions, maze [][]string) int {
+	var (
+		next, visits int
+
+		openCells = config.getOpenNeighbors(maze, s.position)
+		leastMark = 2
+	)
+
+	for _, cell := range openCells {
+		if cell != s.previous {
+			visits += s.marks[getPassage(s.position, cell)]
+		}
+	}
+
+	for _, cell := range openCells {
+		if mark := s.marks[getPassage(s.position, cell)]; cell != s.previous && mark < leastMark {
+			next, leastMark = cell, mark
+		}
+	}
+
+	switch mark := s.marks[getPassage(s.position, s.previous)]; {
+	case s.previous == 0:
+
+	case visits > 0 && mark == 1, next == 0 && mark < 2:
+		next = s.previous
+	}
+
+	if next != 0 {
+		s.marks[getPassage(s.position, next)]++
+	}
+
+	return next
+}
+
+// lookAround adds the cells that can be seen from the current seeker position to the
+// known cells. A cell can be seen if it is along a straight corridor from the seeker.
+func (s *seeker) lookAround(config *Dimensions, maze [][]string) {
+	s.seen[s.position] = true
+
+	for _, direction := range config.getDirections(s.position) {
+		for cell := s.position; ; {
+			next := getNeighbor(config.getCellNeighbors(cell), direction)
+
+			if next == 0 || !isCellFound(config.getOpenNeighbors(maze, cell), next) {
+				break
+			}
+
+			s.seen[next], cell = true, next
+		}
+	}
+}
+
+// exploreSeen returns the next cell on the shortest path to the target if the target has
+// been seen, otherwise the next cell on the shortest path to the closest cell that is
+// known but has not been seen yet. Only the paths of the seen cells are used.
+func (s *seeker) exploreSeen(config *Dimensions, maze [][]string) int {
+	var (
+		current int
+
+		target  = config.getCellNumber(config.FinalPosition)
+		queue   = []int{s.position}
+		parents = map[int]int{s.position: 0}
+	)
+
+	s.lookAround(config, maze)
+
+	for len(queue) > 0 {
+		current, queue = queue[0], queue[1:]
+
+		if current == target || (!s.seen[target] && !s.seen[current]) {
+			for parents[current] != s.position {
+				current = parents[current]
+			}
+			return current
+		}
+
+		if !s.seen[current] {
+			continue
+		}
+
+		for _, neighbor := range config.getOpenNeighbors(maze, current) {
+			if _, ok := parents[neighbor]; !ok {
+				parents[neighbor] = current
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	return 0
+}
+
+// walkRandomly returns a random neighboring cell other than the previous cell.
+// The previous cell is only returned at dead ends.
+func (s *seeker) walkRandomly(config *Dimensions, maze [][]string) int {
+	var cells []int
+
+	openCells := config.getOpenNeighbors(maze, s.position)
+
+	for _, cell := range openCells {
+		if cell != s.previous {
+			cells = append(cells, cell)
+		}
+	}
+
+	if len(cells) == 0 {
+		cells = openCells
+	}
+
+	if len(cells) == 0 {
+		return 0
+	}
+
+	return cells[getRandomNo(len(cells))]
+}
@@ -0,0 +1,112 @@
+package maze
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestNewSeeker tests the functionality of newSeeker
+func TestNewSeeker(t *testing.T) {
+	Convey("TestNewSeeker: Given a seeker strategy", t, func() {
+		Convey("that is invalid, the second value returned should implement an error", func() {
+			opponent, err := newSeeker("dijkstra", 1)
+
+			So(opponent, ShouldBeNil)
+			So(err, ShouldImplement, (*error)(nil))
+			So(err.Error(), ShouldContainSubstring, "Invalid seeker strategy found: dijkstra")
+		})
+
+		Convey("that is valid, a seeker at the provided cell should be returned", func() {
+			for _, strategy := range []string{WallFollower, Tremaux, PartialBFS, RandomWalk} {
+				opponent, err := newSeeker(strategy, 7)
+
+				So(err, ShouldBeNil)
+				So(opponent.strategy, ShouldEqual, strategy)
+				So(opponent.position, ShouldEqual, 7)
+			}
+		})
+	})
+}
+
+// TestSeekerMove tests the functionality of move
+func TestSeekerMove(t *testing.T) {
+	var testFunc = func(strategy string, maxMoves int) {
+		val := &Dimensions{Length: 12, Width: 8}
+		data, err := val.generateMaze(1, Difficulty{})
+
+		So(err, ShouldBeNil)
+
+		opponent, err := newSeeker(strategy, val.getCellNumber(val.StartPosition))
+		So(err, ShouldBeNil)
+
+		for i := 0; i < maxMoves && opponent.position != val.getCellNumber(val.FinalPosition); i++ {
+			previous := opponent.position
+			opponent.move(val, data)
+
+			So(val.getOpenNeighbors(data, previous), ShouldContain, opponent.position)
+		}
+
+		So(opponent.position, ShouldEqual, val.getCellNumber(val.FinalPosition))
+	}
+
+	Convey("TestSeekerMove: Given a perfect maze, the seeker should locate the target", t, func() {
+		Convey("using the wall follower strategy", func() {
+			testFunc(WallFollower, 4*12*8)
+		})
+
+		Convey("using the Trémaux strategy", func() {
+			testFunc(Tremaux, 4*12*8)
+		})
+
+		Convey("using the breadth first search with partial knowledge strategy", func() {
+			testFunc(PartialBFS, 4*12*8)
+		})
+
+		Convey("using the random walk strategy", func() {
+			testFunc(RandomWalk, 1000*12*8)
+		})
+	})
+
+	Convey("TestSeekerMove: Given a seeker that has located the target, it should not move", t, func() {
+		val := &Dimensions{Length: 3, Width: 3}
+		val.FinalPosition = val.getCellAddress(5).MiddleCenter
+		opponent, _ := newSeeker(RandomWalk, 5)
+
+		opponent.move(val, testMaze)
+
+		So(opponent.position, ShouldEqual, 5)
+	})
+}
+
+// TestTremaux tests the functionality of tremaux
+func TestTremaux(t *testing.T) {
+	Convey("TestTremaux: Given a maze without the target, no passage should be used more than twice", t, func() {
+		val := &Dimensions{Length: 3, Width: 3}
+		opponent, _ := newSeeker(Tremaux, 1)
+
+		for i := 0; i < 30; i++ {
+			if next := opponent.tremaux(val, testMaze); next != 0 {
+				opponent.previous, opponent.position = opponent.position, next
+			}
+		}
+
+		for _, mark := range opponent.marks {
+			So(mark, ShouldEqual, 2)
+		}
+
+		So(opponent.marks, ShouldHaveLength, 8)
+	})
+}
+
+// TestLookAround tests the functionality of lookAround
+func TestLookAround(t *testing.T) {
+	Convey("TestLookAround: Given the seeker position, only cells along straight corridors should be seen", t, func() {
+		val := &Dimensions{Length: 3, Width: 3}
+		opponent, _ := newSeeker(PartialBFS, 4)
+
+		opponent.lookAround(val, testMaze)
+
+		So(opponent.seen, ShouldResemble, map[int]bool{4: true, 5: true, 6: true, 7: true})
+	})
+}
@@ -0,0 +1,258 @@
+package maze
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultLetters defines the text used by the letters shape when no text is provided.
+const defaultLetters = "TAPOO"
+
+// shapes defines the built-in maze shapes. Each shape checks if a point is inside it,
+// where x and y range from -1 to 1 between the maze edges with y growing downwards.
+var shapes = map[string]func(x, y float64) bool{
+	"circle": func(x, y float64) bool {
+		return x*x+y*y <= 1
+	},
+	"diamond": func(x, y float64) bool {
+		return math.Abs(x)+math.Abs(y) <= 1
+	},
+	"heart": func(x, y float64) bool {
+		x, y = x*1.2, 0.12-y*1.15
+		return math.Pow(x*x+y*y-1, 3)-x*x*y*y*y <= 0
+	},
+}
+
+// letterRows defines the three by five characters font used by the letters shape.
+var letterRows = map[rune][5]string{
+	'A': {"###", "#.#", "###", "#.#", "#.#"},
+	'B': {"##.", "#.#", "##.", "#.#", "##."},
+	'C': {"###", "#..", "#..", "#..", "###"},
+	'D': {"##.", "#.#", "#.#", "#.#", "##."},
+	'E': {"###", "#..", "##.", "#..", "###"},
+	'F': {"###", "#..", "##.", "#..", "#.."},
+	'G': {"###", "#..", "#.#", "#.#", "###"},
+	'H': {"#.#", "#.#", "###", "#.#", "#.#"},
+	'I': {"###", ".#.", ".#.", ".#.", "###"},
+	'J': {"..#", "..#", "..#", "#.#", "###"},
+	'K': {"#.#", "#.#", "##.", "#.#", "#.#"},
+	'L': {"#..", "#..", "#..", "#..", "###"},
+	'M': {"#.#", "###", "###", "#.#", "#.#"},
+	'N': {"##.", "#.#", "#.#", "#.#", "#.#"},
+	'O': {"###", "#.#", "#.#", "#.#", "###"},
+	'P': {"###", "#.#", "###", "#..", "#.."},
+	'Q': {"###", "#.#", "#.#", "###", "..#"},
+	'R': {"##.", "#.#", "##.", "#.#", "#.#"},
+	'S': {"###", "#..", "###", "..#", "###"},
+	'T': {"###", ".#.", ".#.", ".#.", ".#."},
+	'U': {"#.#", "#.#", "#.#", "#.#", "###"},
+	'V': {"#.#", "#.#", "#.#", "#.#", ".#."},
+	'W': {"#.#", "#.#", "###", "###", "#.#"},
+	'X': {"#.#", "#.#", ".#.", "#.#", "#.#"},
+	'Y': {"#.#", "#.#", ".#.", ".#.", ".#."},
+	'Z': {"###", "..#", ".#.", "#..", "###"},
+}
+
+// getShape returns the shape with the provided name. The name can be a built-in shape, the
+// letters shape optionally followed by a colon and the text to write or the path to a mask file.
+// The built-in shapes and the letters shape are matched regardless of their case.
+// If the shape cannot be found an error is thrown.
+func getShape(name string) (func(x, y float64) bool, error) {
+	if shape, ok := shapes[strings.ToLower(name)]; ok {
+		return shape, nil
+	}
+
+	if strings.EqualFold(name, "letters") {
+		return getLettersShape("")
+	}
+
+	if prefix := len("letters:"); len(name) >= prefix && strings.EqualFold(name[:prefix], "letters:") {
+		return getLettersShape(name[prefix:])
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Invalid shape found: %s. Allowed circle, diamond, heart, letters or a mask file", name)
+	}
+
+	return getMaskShape(strings.Split(strings.TrimRight(string(data), "\n"), "\n"))
+}
+
+// getLettersShape returns a shape that writes the provided text using the letters font.
+// The letters are joined by a line below them so that all of them are part of the maze.
+// If the text has characters that are not letters an error is thrown.
+func getLettersShape(text string) (func(x, y float64) bool, error) {
+	rows := make([]string, 6)
+
+	if text == "" {
+		text = defaultLetters
+	}
+
+	for index, char := range strings.ToUpper(text) {
+		letter, ok := letterRows[char]
+		if !ok {
+			return nil, fmt.Errorf("Invalid letter found: %c. Allowed A to Z", char)
+		}
+
+		if index > 0 {
+			for row := range letter {
+				rows[row] += "."
+			}
+			rows[5] += "#"
+		}
+
+		for row, line := range letter {
+			rows[row] += line
+		}
+		rows[5] += "###"
+	}
+
+	return getMaskShape(rows)
+}
+
+// getMaskShape returns a shape from the provided mask rows. Every character other than
+// a space or a dot is inside the shape. If the mask is empty an error is thrown.
+func getMaskShape(rows []string) (func(x, y float64) bool, error) {
+	columns := 0
+
+	for _, row := range rows {
+		if count := utf8.RuneCountInString(row); count > columns {
+			columns = count
+		}
+	}
+
+	if columns == 0 {
+		return nil, errors.New("mask is empty")
+	}
+
+	return func(x, y float64) bool {
+		row := []rune(rows[getMaskIndex(y, len(rows))])
+		column := getMaskIndex(x, columns)
+
+		return column < len(row) && row[column] != ' ' && row[column] != '.'
+	}, nil
+}
+
+// getMaskIndex converts the provided coordinate ranging from -1 to 1 into an index
+// among the provided number of mask rows or columns.
+func getMaskIndex(point float64, count int) int {
+	index := int((point + 1) / 2 * float64(count))
+
+	if index >= count {
+		return count - 1
+	}
+	return index
+}
+
+// applyShape masks out the cells whose centers are outside the provided shape on every floor.
+// Only the largest group of the cells inside the shape that are joined together is kept so
+// that every cell left can be visited. If less than two cells are left an error is thrown.
+func (config *Dimensions) applyShape(shape func(x, y float64) bool) error {
+	var (
+		largest map[int]bool
+
+		area    = config.Length * config.Width
+		grouped = map[int]bool{}
+	)
+
+	config.masked = map[int]bool{}
+
+	for cell := 1; cell <= area; cell++ {
+		x := (2*float64((cell-1)%config.Length)+1)/float64(config.Length) - 1
+		y := (2*float64((cell-1)/config.Length)+1)/float64(config.Width) - 1
+
+		if !shape(x, y) {
+			config.masked[cell] = true
+		}
+	}
+
+	for cell := 1; cell <= area; cell++ {
+		if grouped[cell] {
+			continue
+		}
+
+		group := config.getJoinedCells(cell)
+
+		for joined := range group {
+			grouped[joined] = true
+		}
+
+		if len(group) > len(largest) {
+			largest = group
+		}
+	}
+
+	if len(largest) < 2 {
+		config.masked = nil
+		return errors.New("shape is too small for the current maze size")
+	}
+
+	for cell := 1; cell <= config.getCellsCount(); cell++ {
+		if !largest[(cell-1)%area+1] {
+			config.masked[cell] = true
+		}
+	}
+
+	return nil
+}
+
+// getJoinedCells returns the cells that can be reached from the provided cell on its floor
+// if all the walls were removed. An empty group is returned for masked out cells.
+func (config *Dimensions) getJoinedCells(cellNo int) map[int]bool {
+	var (
+		current int
+
+		queue  = []int{cellNo}
+		joined = map[int]bool{cellNo: true}
+	)
+
+	if config.masked[cellNo] {
+		return nil
+	}
+
+	for len(queue) > 0 {
+		current, queue = queue[0], queue[1:]
+		neighbors := config.getCellNeighbors(current)
+
+		for _, direction := range neighborDirections {
+			if neighbor := getNeighbor(neighbors, direction); neighbor != 0 && !joined[neighbor] {
+				joined[neighbor] = true
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	return joined
+}
+
+// blankMaskedCells replaces the walls surrounding the masked out cells with blank spaces.
+// Walls shared with the cells inside the shape are kept as the maze edges.
+func (config *Dimensions) blankMaskedCells(maze [][]string) {
+	for cell := range config.masked {
+		addr := config.getCellAddress(cell)
+
+		for _, point := range [][]int{addr.BottomCenter, addr.BottomLeft, addr.BottomRight,
+			addr.MiddleLeft, addr.MiddleRight, addr.TopCenter, addr.TopLeft, addr.TopRight} {
+			if !config.isNextToCell(point) {
+				maze[point[0]][point[1]] = strings.Repeat(" ", utf8.RuneCountInString(maze[point[0]][point[1]]))
+			}
+		}
+	}
+}
+
+// isNextToCell checks if the provided point belongs to any cell that is not masked out.
+func (config *Dimensions) isNextToCell(point []int) bool {
+	for row := point[0] - 1; row <= point[0]+1; row++ {
+		for column := point[1] - 1; column <= point[1]+1; column++ {
+			if cell := config.getCellNumber([]int{row, column}); cell != 0 && !config.masked[cell] {
+				return true
+			}
+		}
+	}
+	return false
+}
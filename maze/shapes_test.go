@@ -0,0 +1,117 @@
+package maze
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestGetShape tests the functionality of getShape
+func TestGetShape(t *testing.T) {
+	Convey("TestGetShape: Given a shape name", t, func() {
+		Convey("that is a built-in shape, the shape should be returned", func() {
+			for _, name := range []string{"circle", "Diamond", "heart", "letters", "LETTERS:HI", "letters:Maze"} {
+				shape, err := getShape(name)
+
+				So(err, ShouldBeNil)
+				So(shape(0, 0.9), ShouldBeTrue)
+			}
+		})
+
+		Convey("that is a mask file, the shape from the file should be returned", func() {
+			path := filepath.Join(t.TempDir(), "mask.txt")
+			So(os.WriteFile(path, []byte("#.\n##\n"), 0644), ShouldBeNil)
+
+			shape, err := getShape(path)
+
+			So(err, ShouldBeNil)
+			So(shape(-0.5, -0.5), ShouldBeTrue)
+			So(shape(0.5, -0.5), ShouldBeFalse)
+			So(shape(0.5, 0.5), ShouldBeTrue)
+		})
+
+		Convey("that is a mask file named after the letters shape, the file should be used", func() {
+			dir, err := os.Getwd()
+			So(err, ShouldBeNil)
+
+			So(os.Chdir(t.TempDir()), ShouldBeNil)
+			defer os.Chdir(dir)
+
+			So(os.WriteFile("letters.txt", []byte("#.\n##\n"), 0644), ShouldBeNil)
+
+			shape, err := getShape("letters.txt")
+
+			So(err, ShouldBeNil)
+			So(shape(0.5, -0.5), ShouldBeFalse)
+			So(shape(0.5, 0.5), ShouldBeTrue)
+		})
+
+		Convey("that is neither a built-in shape nor a file, an error should be returned", func() {
+			_, err := getShape("triangle")
+			So(err, ShouldNotBeNil)
+
+			_, err = getShape("letters:2")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+// TestApplyShape tests the functionality of applyShape
+func TestApplyShape(t *testing.T) {
+	Convey("TestApplyShape: Given a mask with two separate groups of cells", t, func() {
+		val := &Dimensions{Length: 4, Width: 2, Depth: 2}
+		shape, err := getMaskShape([]string{"#.##", "..##"})
+
+		So(err, ShouldBeNil)
+		So(val.applyShape(shape), ShouldBeNil)
+
+		Convey("only the largest group should be kept on every floor", func() {
+			So(val.masked, ShouldResemble, map[int]bool{1: true, 2: true, 5: true, 6: true,
+				9: true, 10: true, 13: true, 14: true})
+		})
+
+		Convey("the masked out cells should never be neighbors", func() {
+			So(val.getCellNeighbors(3), ShouldResemble, cellNeighbors{Above: 11, Right: 4, Bottom: 7})
+			So(val.getCellNeighbors(2), ShouldResemble, cellNeighbors{})
+		})
+	})
+
+	Convey("TestApplyShape: Given a mask that leaves a single cell, an error should be returned", t, func() {
+		val := &Dimensions{Length: 2, Width: 2}
+		shape, err := getMaskShape([]string{"#.", ".#"})
+
+		So(err, ShouldBeNil)
+		So(val.applyShape(shape), ShouldNotBeNil)
+	})
+}
+
+// TestGenerateMazeShape tests the functionality of generateMaze inside a shape
+func TestGenerateMazeShape(t *testing.T) {
+	Convey("TestGenerateMazeShape: Given a maze inside the circle shape", t, func() {
+		val := &Dimensions{Length: 20, Width: 10}
+
+		So(val.applyShape(shapes["circle"]), ShouldBeNil)
+
+		data, err := val.generateMaze(1, Difficulty{})
+
+		So(err, ShouldBeNil)
+
+		Convey("every cell inside the shape should be reachable", func() {
+			distances := val.getDistances(data, val.getCellNumber(val.StartPosition))
+
+			So(distances, ShouldHaveLength, val.getCellsCount()-len(val.masked))
+
+			for cell := range val.masked {
+				So(distances, ShouldNotContainKey, cell)
+			}
+		})
+
+		Convey("the corners outside the shape should be drawn blank", func() {
+			So(data[0][0], ShouldEqual, " ")
+			So(data[1][1], ShouldEqual, "   ")
+			So(data[0][1], ShouldEqual, "   ")
+		})
+	})
+}
@@ -0,0 +1,131 @@
+package maze
+
+// getCellNumber returns the number of the cell whose MiddleCenter is the provided point.
+// Zero is returned if the point does not belong to any cell in the maze.
+func (config *Dimensions) getCellNumber(point []int) int {
+	if len(point) != 2 || point[0] < 0 || point[1] < 0 {
+		return 0
+	}
+
+	floor := config.getFloor(point)
+	cellNo := config.getTopology().getCellNumber(config, []int{point[0] - floor*config.getFloorRows(), point[1]})
+
+	if cellNo == 0 || floor >= config.getFloors() {
+		return 0
+	}
+
+	return (floor * config.Length * config.Width) + cellNo
+}
+
+// getOpenNeighbors returns the neighboring cells that share a common path with the provided cell.
+func (config *Dimensions) getOpenNeighbors(maze [][]string, cellNo int) []int {
+	return config.getPassableNeighbors(maze, cellNo, nil)
+}
+
+// getPassableNeighbors returns the neighboring cells that share a common path with the
+// provided cell or are separated from it by a door whose key is found in the keys provided.
+// One-way gates are only crossed in their direction while a neighboring teleporter is
+// replaced by its pair, since that is where the player ends up. A tunnel under a bridge
+// leads to the cell beyond the bridge and cannot be entered from the bridge itself.
+// Stairs lead to the cell on the floor above or below.
+func (config *Dimensions) getPassableNeighbors(maze [][]string, cellNo int, keys map[string]bool) []int {
+	var (
+		openCells []int
+
+		addr      = config.getCellAddress(cellNo)
+		neighbors = config.getCellNeighbors(cellNo)
+		content   = config.getCellContent(maze, cellNo)
+	)
+
+	for _, direction := range neighborDirections {
+		next := getNeighbor(neighbors, direction)
+
+		if next == 0 || isTunnel(content, direction) {
+			continue
+		}
+
+		wall := getWall(addr, direction)
+		if !isWallPassable(maze[wall[0]][wall[1]], direction) && !keys[getDoorKey(maze[wall[0]][wall[1]])] {
+			continue
+		}
+
+		if isTunnel(config.getCellContent(maze, next), direction) {
+			next = getNeighbor(config.getCellNeighbors(next), direction)
+		}
+
+		openCells = append(openCells, config.getTeleporterExit(next))
+	}
+
+	for _, direction := range floorDirections {
+		if hasStairs(content, direction) {
+			openCells = append(openCells, config.getTeleporterExit(getNeighbor(neighbors, direction)))
+		}
+	}
+
+	return openCells
+}
+
+// solveMaze returns the shortest path between the two provided cells using the breadth
+// first search algorithm. The path returned includes both the start and the final cell.
+// If several shortest paths exist, e.g. in braided mazes, the neighbors are always explored
+// in the same order so that the same path is returned every time.
+// An empty path is returned if no path exists between the two cells.
+func (config *Dimensions) solveMaze(maze [][]string, startCell, finalCell int) []int {
+	var (
+		current int
+		path    []int
+
+		queue   = []int{startCell}
+		parents = map[int]int{startCell: 0}
+	)
+
+	for len(queue) > 0 {
+		current, queue = queue[0], queue[1:]
+
+		if current == finalCell {
+			for ; current != 0; current = parents[current] {
+				path = append([]int{current}, path...)
+			}
+			return path
+		}
+
+		for _, neighbor := range config.getOpenNeighbors(maze, current) {
+			if _, ok := parents[neighbor]; !ok {
+				parents[neighbor] = current
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	return path
+}
+
+// getDistances returns the length of the shortest path from the provided cell to every
+// other cell that can be reached from it, calculated using the breadth first search algorithm.
+func (config *Dimensions) getDistances(maze [][]string, startCell int) map[int]int {
+	return config.getPassableDistances(maze, startCell, nil)
+}
+
+// getPassableDistances returns the length of the shortest path from the provided cell to every
+// other cell that can be reached from it using only the doors whose keys are provided.
+func (config *Dimensions) getPassableDistances(maze [][]string, startCell int, keys map[string]bool) map[int]int {
+	var (
+		current int
+
+		queue     = []int{startCell}
+		distances = map[int]int{startCell: 0}
+	)
+
+	for len(queue) > 0 {
+		current, queue = queue[0], queue[1:]
+
+		for _, neighbor := range config.getPassableNeighbors(maze, current, keys) {
+			if _, ok := distances[neighbor]; !ok {
+				distances[neighbor] = distances[current] + 1
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	return distances
+}
@@ -0,0 +1,130 @@
+package maze
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// testMaze defines a 3 by 3 cells maze with a single path between cell 1 and cell 9.
+var testMaze = [][]string{
+	{"|", "---", "|", "---", "|", "---", "|"},
+	{"|", "   ", " ", "   ", "|", "   ", "|"},
+	{"|", "---", "|", "   ", "|", "   ", "|"},
+	{"|", "   ", " ", "   ", " ", "   ", "|"},
+	{"|", "   ", "|", "---", "|", "   ", "|"},
+	{"|", "   ", "|", "   ", " ", "   ", "|"},
+	{"|", "---", "|", "---", "|", "---", "|"},
+}
+
+// TestGetCellNumber tests the functionality of getCellNumber
+func TestGetCellNumber(t *testing.T) {
+	val := &Dimensions{Length: 6, Width: 5}
+
+	Convey("TestGetCellNumber: Given a point in the maze", t, func() {
+		Convey("that is a MiddleCenter of a cell, the cell number should be returned", func() {
+			for i := 1; i <= (val.Length * val.Width); i++ {
+				So(val.getCellNumber(val.getCellAddress(i).MiddleCenter), ShouldEqual, i)
+			}
+		})
+
+		Convey("that is not a MiddleCenter of any cell, zero should be returned", func() {
+			for _, point := range [][]int{{}, {0, 1}, {1, 0}, {2, 2}, {11, 1}, {1, 13}} {
+				So(val.getCellNumber(point), ShouldEqual, 0)
+			}
+		})
+	})
+}
+
+// TestGetOpenNeighbors tests the functionality of getOpenNeighbors
+func TestGetOpenNeighbors(t *testing.T) {
+	val := &Dimensions{Length: 3, Width: 3}
+
+	Convey("TestGetOpenNeighbors: Given a cell number", t, func() {
+		Convey("only the neighbors that share a common path should be returned", func() {
+			for cell, expected := range map[int][]int{
+				1: {2}, 2: {1, 5}, 3: {6}, 4: {5, 7},
+				5: {2, 4, 6}, 8: {9}, 9: {6, 8},
+			} {
+				So(val.getOpenNeighbors(testMaze, cell), ShouldHaveLength, len(expected))
+
+				for _, neighbor := range expected {
+					So(val.getOpenNeighbors(testMaze, cell), ShouldContain, neighbor)
+				}
+			}
+		})
+	})
+}
+
+// TestSolveMaze tests the functionality of solveMaze
+func TestSolveMaze(t *testing.T) {
+	val := &Dimensions{Length: 3, Width: 3}
+
+	Convey("TestSolveMaze: Given the start and the final cell", t, func() {
+		Convey("that are connected, the shortest path between them should be returned", func() {
+			So(val.solveMaze(testMaze, 1, 9), ShouldResemble, []int{1, 2, 5, 6, 9})
+			So(val.solveMaze(testMaze, 7, 3), ShouldResemble, []int{7, 4, 5, 6, 3})
+			So(val.solveMaze(testMaze, 8, 8), ShouldResemble, []int{8})
+		})
+
+		Convey("that have more than one shortest path between them, the same shortest path should always be returned", func() {
+			loop := &Dimensions{Length: 2, Width: 2}
+			data := [][]string{
+				{"|", "---", "|", "---", "|"},
+				{"|", "   ", " ", "   ", "|"},
+				{"|", "   ", "|", "   ", "|"},
+				{"|", "   ", " ", "   ", "|"},
+				{"|", "---", "|", "---", "|"},
+			}
+
+			for i := 0; i < 5; i++ {
+				So(loop.solveMaze(data, 1, 4), ShouldResemble, []int{1, 3, 4})
+			}
+		})
+
+		Convey("that are in a generated maze, a path should always exist between them", func() {
+			maze := &Dimensions{Length: 12, Width: 8}
+			data, err := maze.generateMaze(1, presets["insane"])
+
+			So(err, ShouldBeNil)
+
+			path := maze.solveMaze(data, maze.getCellNumber(maze.StartPosition),
+				maze.getCellNumber(maze.FinalPosition))
+
+			So(path, ShouldNotBeEmpty)
+			So(path[0], ShouldEqual, maze.getCellNumber(maze.StartPosition))
+			So(path[len(path)-1], ShouldEqual, maze.getCellNumber(maze.FinalPosition))
+		})
+	})
+}
+
+// TestGetDistances tests the functionality of getDistances
+func TestGetDistances(t *testing.T) {
+	val := &Dimensions{Length: 3, Width: 3}
+
+	Convey("TestGetDistances: Given a start cell, the shortest distance to every cell should be returned", t, func() {
+		So(val.getDistances(testMaze, 1), ShouldResemble, map[int]int{
+			1: 0, 2: 1, 5: 2, 4: 3, 6: 3, 7: 4, 3: 4, 9: 4, 8: 5,
+		})
+	})
+}
+
+// TestGetPassableDistances tests the functionality of getPassableDistances
+func TestGetPassableDistances(t *testing.T) {
+	Convey("TestGetPassableDistances: Given a maze with a door", t, func() {
+		val := &Dimensions{Length: 3, Width: 3}
+		data := copyMaze(testMaze)
+		val.setWall(data, 5, 6, "A")
+
+		Convey("without its key, the cells behind the door should not be reached", func() {
+			So(val.getPassableDistances(data, 1, nil), ShouldResemble, map[int]int{
+				1: 0, 2: 1, 5: 2, 4: 3, 7: 4,
+			})
+		})
+
+		Convey("with its key, the door should be crossed", func() {
+			So(val.getPassableDistances(data, 1, map[string]bool{"a": true}),
+				ShouldResemble, val.getDistances(testMaze, 1))
+		})
+	})
+}
@@ -0,0 +1,119 @@
+package maze
+
+import "fmt"
+
+const (
+	// FarthestTarget places the target on the cell with the longest shortest path from the
+	// starting point. If several cells are equally far, one of them is picked randomly.
+	FarthestTarget = "farthest"
+
+	// DeadEndTarget places the target on a random dead end of the maze.
+	DeadEndTarget = "deadend"
+
+	// DistanceTarget places the target on a random cell that is at least the minimum
+	// distance (in moves) away from the starting point.
+	DistanceTarget = "distance"
+
+	// ChosenTarget lets the hiding player choose the cell where the target is placed.
+	ChosenTarget = "chosen"
+)
+
+// placeTarget places the target on the maze using the provided placement strategy.
+// minDistance is only used by the DistanceTarget strategy, if it is not greater than zero or
+// cannot be reached in the maze, half the distance to the farthest cell is used instead.
+// The ChosenTarget strategy keeps the current target until the hiding player selects one.
+// If an invalid strategy is used an error is thrown.
+func (config *Dimensions) placeTarget(maze [][]string, strategy string, minDistance int) error {
+	switch strategy {
+	case FarthestTarget:
+		config.setTarget(maze, config.getFarthestCell(maze))
+
+	case DeadEndTarget:
+		config.setTarget(maze, config.getRandomDeadEnd(maze))
+
+	case DistanceTarget:
+		config.setTarget(maze, config.getRandomDistantCell(maze, minDistance))
+
+	case ChosenTarget:
+
+	default:
+		return fmt.Errorf("Invalid target placement found: %s. Allowed %s, %s, %s and %s",
+			strategy, FarthestTarget, DeadEndTarget, DistanceTarget, ChosenTarget)
+	}
+
+	return nil
+}
+
+// setTarget places the target on the provided cell and updates the length of the
+// shortest path between the starting point and the target.
+func (config *Dimensions) setTarget(maze [][]string, cellNo int) {
+	config.FinalPosition = config.getCellAddress(cellNo).MiddleCenter
+	config.PathLength = len(config.solveMaze(maze, config.getCellNumber(config.StartPosition), cellNo)) - 1
+}
+
+// getFarthestCell returns the cell with the longest shortest path from the starting point.
+func (config *Dimensions) getFarthestCell(maze [][]string) int {
+	var (
+		farthest []int
+		maxDist  int
+	)
+
+	for cell, distance := range config.getDistances(maze, config.getCellNumber(config.StartPosition)) {
+		switch {
+		case distance > maxDist:
+			farthest, maxDist = []int{cell}, distance
+
+		case distance == maxDist:
+			farthest = append(farthest, cell)
+		}
+	}
+
+	return farthest[getRandomNo(len(farthest))]
+}
+
+// getRandomDeadEnd returns a random dead end other than the starting point. If the maze has
+// no other dead ends e.g. after braiding, the farthest cell is returned instead.
+func (config *Dimensions) getRandomDeadEnd(maze [][]string) int {
+	var deadEnds []int
+
+	for _, cell := range config.getDeadEnds(maze) {
+		if cell != config.getCellNumber(config.StartPosition) {
+			deadEnds = append(deadEnds, cell)
+		}
+	}
+
+	if len(deadEnds) == 0 {
+		return config.getFarthestCell(maze)
+	}
+
+	return deadEnds[getRandomNo(len(deadEnds))]
+}
+
+// getRandomDistantCell returns a random cell that is at least minDistance moves away from
+// the starting point.
+func (config *Dimensions) getRandomDistantCell(maze [][]string, minDistance int) int {
+	var (
+		cells   []int
+		maxDist int
+
+		distances = config.getDistances(maze, config.getCellNumber(config.StartPosition))
+	)
+
+	for _, distance := range distances {
+		if distance > maxDist {
+			maxDist = distance
+		}
+	}
+
+	if minDistance <= 0 || minDistance > maxDist {
+		minDistance = getCeiledDivisor(maxDist, 2)
+	}
+
+	for cell, distance := range distances {
+		if distance >= minDistance {
+			cells = append(cells, cell)
+		}
+	}
+
+	return cells[getRandomNo(len(cells))]
+}
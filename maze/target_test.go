@@ -0,0 +1,71 @@
+package maze
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestPlaceTarget tests the functionality of placeTarget
+func TestPlaceTarget(t *testing.T) {
+	var val = &Dimensions{Length: 3, Width: 3}
+
+	Convey("TestPlaceTarget: Given the maze and the placement strategy", t, func() {
+		val.StartPosition = val.getCellAddress(1).MiddleCenter
+
+		Convey("that is invalid, an error should be returned", func() {
+			err := val.placeTarget(testMaze, "nearest", 0)
+
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "Invalid target placement found: nearest")
+		})
+
+		Convey("that is farthest, the target should be placed on the farthest cell", func() {
+			So(val.placeTarget(testMaze, FarthestTarget, 0), ShouldBeNil)
+			So(val.FinalPosition, ShouldResemble, val.getCellAddress(8).MiddleCenter)
+			So(val.PathLength, ShouldEqual, 5)
+		})
+
+		Convey("that is deadend, the target should be placed on a dead end other than the start", func() {
+			So(val.placeTarget(testMaze, DeadEndTarget, 0), ShouldBeNil)
+			So([]int{3, 7, 8}, ShouldContain, val.getCellNumber(val.FinalPosition))
+			So(val.PathLength, ShouldBeGreaterThanOrEqualTo, 4)
+		})
+
+		Convey("that is distance, the target should be placed at least the minimum distance away", func() {
+			So(val.placeTarget(testMaze, DistanceTarget, 4), ShouldBeNil)
+			So([]int{3, 7, 8, 9}, ShouldContain, val.getCellNumber(val.FinalPosition))
+			So(val.PathLength, ShouldBeGreaterThanOrEqualTo, 4)
+
+			So(val.placeTarget(testMaze, DistanceTarget, 50), ShouldBeNil)
+			So(val.PathLength, ShouldBeGreaterThanOrEqualTo, 3)
+		})
+
+		Convey("that is chosen, the current target should not be changed", func() {
+			val.setTarget(testMaze, 6)
+
+			So(val.placeTarget(testMaze, ChosenTarget, 0), ShouldBeNil)
+			So(val.FinalPosition, ShouldResemble, val.getCellAddress(6).MiddleCenter)
+			So(val.PathLength, ShouldEqual, 3)
+		})
+	})
+}
+
+// TestGetFarthestCell tests the functionality of getFarthestCell
+func TestGetFarthestCell(t *testing.T) {
+	Convey("TestGetFarthestCell: Given a generated maze, no cell should be farther than the cell returned", t, func() {
+		val := &Dimensions{Length: 12, Width: 8}
+		data, err := val.generateMaze(1, presets["normal"])
+
+		So(err, ShouldBeNil)
+
+		distances := val.getDistances(data, val.getCellNumber(val.StartPosition))
+		farthest := distances[val.getFarthestCell(data)]
+
+		for _, distance := range distances {
+			So(distance, ShouldBeLessThanOrEqualTo, farthest)
+		}
+
+		So(val.PathLength, ShouldEqual, farthest)
+	})
+}
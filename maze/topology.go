@@ -0,0 +1,383 @@
+package maze
+
+import "fmt"
+
+const (
+	// SquareTopology creates mazes of square cells with up to four neighbors each.
+	SquareTopology = "square"
+
+	// HexTopology creates mazes of hexagonal cells with up to six neighbors each.
+	// Every other column of cells is shifted half a cell downwards.
+	HexTopology = "hex"
+
+	// TriangleTopology creates mazes of triangular cells with up to three neighbors each.
+	// The cells in a row alternately point up and down.
+	TriangleTopology = "triangle"
+)
+
+// topology defines the shape of the maze cells and how they are laid out on the terminal.
+// Every floor is laid out as rows of alternating one character and three characters wide
+// parts. The three characters wide parts hold the cell contents and the horizontal walls
+// while the one character wide parts hold the remaining walls. The cells are numbered
+// from left to right and then from top to bottom.
+type topology interface {
+	// getDirections returns the directions of the sides of the provided cell in clockwise order.
+	getDirections(config *Dimensions, cellNo int) []string
+
+	// getRows returns the number of rows used to draw a single floor of the maze.
+	getRows(config *Dimensions) int
+
+	// getCenter returns the point holding the content of the provided cell.
+	getCenter(config *Dimensions, cellNo int) []int
+
+	// getCellNumber returns the cell whose content is held by the provided point.
+	// Zero is returned if the point does not hold the content of any cell.
+	getCellNumber(config *Dimensions, point []int) int
+
+	// getNeighbors returns the neighbors of the provided cell.
+	getNeighbors(config *Dimensions, cellNo int) cellNeighbors
+
+	// createFloor creates the rows of a floor where every cell is surrounded by walls.
+	// The provided wall characters are used for the vertical and the horizontal walls.
+	createFloor(config *Dimensions, chars []string) [][]string
+}
+
+// topologies defines the grid topologies that a maze can be created with.
+var topologies = map[string]topology{
+	SquareTopology:   squareGrid{},
+	HexTopology:      hexGrid{},
+	TriangleTopology: triangleGrid{},
+}
+
+// walls defines the point of the cell address that holds the wall found in every direction.
+// Hexagonal cells use the left and the right points for their upper side walls.
+var walls = map[string]func(addr cellAddress) []int{
+	"UP":        func(addr cellAddress) []int { return addr.TopCenter },
+	"DOWN":      func(addr cellAddress) []int { return addr.BottomCenter },
+	"LEFT":      func(addr cellAddress) []int { return addr.MiddleLeft },
+	"RIGHT":     func(addr cellAddress) []int { return addr.MiddleRight },
+	"UPLEFT":    func(addr cellAddress) []int { return addr.MiddleLeft },
+	"UPRIGHT":   func(addr cellAddress) []int { return addr.MiddleRight },
+	"DOWNLEFT":  func(addr cellAddress) []int { return addr.BottomLeft },
+	"DOWNRIGHT": func(addr cellAddress) []int { return addr.BottomRight },
+}
+
+// sideMoves defines the directions tried when moving left or right from a hexagonal cell.
+var sideMoves = map[string][]string{
+	"LEFT":  {"UPLEFT", "DOWNLEFT"},
+	"RIGHT": {"UPRIGHT", "DOWNRIGHT"},
+}
+
+// oppositeDirections defines the direction leading back to the previous cell after a move.
+var oppositeDirections = map[string]string{
+	"UP":        "DOWN",
+	"DOWN":      "UP",
+	"LEFT":      "RIGHT",
+	"RIGHT":     "LEFT",
+	"UPLEFT":    "DOWNRIGHT",
+	"DOWNRIGHT": "UPLEFT",
+	"UPRIGHT":   "DOWNLEFT",
+	"DOWNLEFT":  "UPRIGHT",
+}
+
+// neighborDirections defines the order in which the neighbors of a cell are always checked.
+var neighborDirections = []string{"DOWN", "DOWNLEFT", "DOWNRIGHT", "LEFT", "RIGHT", "UP", "UPLEFT", "UPRIGHT"}
+
+// getTopology returns the name of the topology matching the provided name.
+// If an invalid name is used an error is thrown.
+func getTopology(name string) (string, error) {
+	if _, ok := topologies[name]; ok {
+		return name, nil
+	}
+
+	return name, fmt.Errorf("Invalid topology found: %s. Allowed %s, %s and %s",
+		name, SquareTopology, HexTopology, TriangleTopology)
+}
+
+// HasSideMoves checks if the cells of the provided topology have upper and lower side
+// neighbors that are reached using the UPLEFT, UPRIGHT, DOWNLEFT and DOWNRIGHT directions.
+func HasSideMoves(topology string) bool {
+	return topology == HexTopology
+}
+
+// getTopology returns the topology of the maze. Square cells are used by default.
+func (config *Dimensions) getTopology() topology {
+	if grid, ok := topologies[config.Topology]; ok {
+		return grid
+	}
+	return squareGrid{}
+}
+
+// getWall returns the point of the wall found in the provided direction of the cell address.
+// Nil is returned if no wall can be found in that direction, e.g. between floors.
+func getWall(addr cellAddress, direction string) []int {
+	if wall, ok := walls[direction]; ok {
+		return wall(addr)
+	}
+	return nil
+}
+
+// createRows creates the provided number of rows made up of blank parts.
+// Every row ends with a new line.
+func createRows(config *Dimensions, count int) [][]string {
+	rows := make([][]string, count)
+
+	for i := range rows {
+		for k := 0; k < config.Length; k++ {
+			rows[i] = append(rows[i], " ", "   ")
+		}
+		rows[i] = append(rows[i], " ", "\n")
+	}
+
+	return rows
+}
+
+// squareGrid defines the topology of square cells. If the maze wraps around, the cells along
+// an edge are neighbors of the cells along the opposite edge. An edge only wraps around if more
+// than two cells are found across the maze, otherwise the same two cells would be joined twice.
+type squareGrid struct{}
+
+func (squareGrid) getDirections(config *Dimensions, cellNo int) []string {
+	return []string{"UP", "RIGHT", "DOWN", "LEFT"}
+}
+
+func (squareGrid) getRows(config *Dimensions) int {
+	return (2 * config.Width) + 1
+}
+
+func (squareGrid) getCenter(config *Dimensions, cellNo int) []int {
+	row, column := (cellNo-1)/config.Length, (cellNo-1)%config.Length
+	return []int{(2 * row) + 1, (2 * column) + 1}
+}
+
+func (squareGrid) getCellNumber(config *Dimensions, point []int) int {
+	if point[0]%2 == 0 || point[1]%2 == 0 {
+		return 0
+	}
+
+	row, column := (point[0]+1)/2, (point[1]+1)/2
+
+	if row < 1 || row > config.Width || column < 1 || column > config.Length {
+		return 0
+	}
+
+	return ((row - 1) * config.Length) + column
+}
+
+func (squareGrid) getNeighbors(config *Dimensions, cellNo int) cellNeighbors {
+	var (
+		right     = cellNo + 1
+		left      = cellNo - 1
+		top       = cellNo - config.Length
+		bottom    = cellNo + config.Length
+		area      = config.Length * config.Width
+		neighbors = cellNeighbors{}
+	)
+
+	if config.Wrap && config.Length > 2 {
+		switch {
+		case cellNo%config.Length == 0:
+			right -= config.Length
+		case cellNo%config.Length == 1:
+			left += config.Length
+		}
+	}
+
+	if config.Wrap && config.Width > 2 {
+		switch {
+		case top < 1:
+			top += area
+		case bottom > area:
+			bottom -= area
+		}
+	}
+
+	if getCeiledDivisor(right, config.Length) == getCeiledDivisor(cellNo, config.Length) {
+		neighbors.Right = right
+	}
+
+	if getCeiledDivisor(left, config.Length) == getCeiledDivisor(cellNo, config.Length) {
+		neighbors.Left = left
+	}
+
+	if top > 0 {
+		neighbors.Top = top
+	}
+
+	if bottom <= area {
+		neighbors.Bottom = bottom
+	}
+
+	return neighbors
+}
+
+func (squareGrid) createFloor(config *Dimensions, chars []string) [][]string {
+	var data [][]string
+
+	for i := 0; i < (2*config.Width)+1; i++ {
+		var val []string
+
+		for k := 0; k < config.Length+1; k++ {
+			val = append(val, chars[0])
+
+			switch {
+			case k != config.Length && i%2 == 0:
+				val = append(val, chars[1])
+			case k != config.Length && i%2 != 0:
+				val = append(val, "   ")
+			default:
+				val = append(val, "\n")
+			}
+		}
+
+		data = append(data, val)
+	}
+
+	return data
+}
+
+// triangleGrid defines the topology of triangular cells. A cell points up if the sum of its
+// row and column is even and has a neighbor below it, otherwise it points down and has a
+// neighbor above it. The cells are laid out like square cells with slanted side walls.
+type triangleGrid struct{}
+
+// isPointingUp checks if the provided cell points up.
+func (triangleGrid) isPointingUp(config *Dimensions, cellNo int) bool {
+	return ((cellNo-1)/config.Length+(cellNo-1)%config.Length)%2 == 0
+}
+
+func (grid triangleGrid) getDirections(config *Dimensions, cellNo int) []string {
+	if grid.isPointingUp(config, cellNo) {
+		return []string{"RIGHT", "DOWN", "LEFT"}
+	}
+	return []string{"UP", "RIGHT", "LEFT"}
+}
+
+func (triangleGrid) getRows(config *Dimensions) int {
+	return squareGrid{}.getRows(config)
+}
+
+func (triangleGrid) getCenter(config *Dimensions, cellNo int) []int {
+	return squareGrid{}.getCenter(config, cellNo)
+}
+
+func (triangleGrid) getCellNumber(config *Dimensions, point []int) int {
+	return squareGrid{}.getCellNumber(config, point)
+}
+
+func (grid triangleGrid) getNeighbors(config *Dimensions, cellNo int) cellNeighbors {
+	neighbors := squareGrid{}.getNeighbors(config, cellNo)
+
+	if grid.isPointingUp(config, cellNo) {
+		neighbors.Top = 0
+	} else {
+		neighbors.Bottom = 0
+	}
+
+	return neighbors
+}
+
+func (grid triangleGrid) createFloor(config *Dimensions, chars []string) [][]string {
+	data := createRows(config, (2*config.Width)+1)
+
+	for cell := 1; cell <= config.Length*config.Width; cell++ {
+		var (
+			center       = grid.getCenter(config, cell)
+			left, right  = "\\", "/"
+			base, across = center[0] - 1, center[0] + 1
+		)
+
+		if grid.isPointingUp(config, cell) {
+			left, right, base, across = "/", "\\", center[0]+1, center[0]-1
+		}
+
+		data[center[0]][center[1]-1] = left
+		data[center[0]][center[1]+1] = right
+		data[base][center[1]] = chars[1]
+
+		// The edges of the maze are closed even where the cells only meet at a corner.
+		if across == 0 || across == 2*config.Width {
+			data[across][center[1]] = chars[1]
+		}
+	}
+
+	return data
+}
+
+// hexGrid defines the topology of hexagonal cells with flat tops. The cells in the odd columns
+// are shifted half a cell downwards, so the upper and lower side neighbors of a cell depend on
+// its column. The upper side walls are found next to the cell content while the lower side
+// walls and the bottom wall are found on the row below it.
+type hexGrid struct{}
+
+func (hexGrid) getDirections(config *Dimensions, cellNo int) []string {
+	return []string{"UP", "UPRIGHT", "DOWNRIGHT", "DOWN", "DOWNLEFT", "UPLEFT"}
+}
+
+func (hexGrid) getRows(config *Dimensions) int {
+	return (2 * config.Width) + 2
+}
+
+func (hexGrid) getCenter(config *Dimensions, cellNo int) []int {
+	row, column := (cellNo-1)/config.Length, (cellNo-1)%config.Length
+	return []int{(2 * row) + 1 + column%2, (2 * column) + 1}
+}
+
+func (hexGrid) getCellNumber(config *Dimensions, point []int) int {
+	if point[1]%2 == 0 || point[1] < 1 || point[1] >= 2*config.Length {
+		return 0
+	}
+
+	column := (point[1] - 1) / 2
+	row := point[0] - 1 - column%2
+
+	if row < 0 || row%2 != 0 || row/2 >= config.Width {
+		return 0
+	}
+
+	return (row/2)*config.Length + column + 1
+}
+
+func (hexGrid) getNeighbors(config *Dimensions, cellNo int) cellNeighbors {
+	var (
+		neighbors = cellNeighbors{}
+
+		row, column = (cellNo - 1) / config.Length, (cellNo - 1) % config.Length
+
+		// The row of the upper side neighbors, the lower side neighbors are on the next row.
+		upper = row - 1 + column%2
+	)
+
+	cell := func(row, column int) int {
+		if row < 0 || row >= config.Width || column < 0 || column >= config.Length {
+			return 0
+		}
+		return row*config.Length + column + 1
+	}
+
+	neighbors.Top = cell(row-1, column)
+	neighbors.Bottom = cell(row+1, column)
+	neighbors.UpperLeft = cell(upper, column-1)
+	neighbors.UpperRight = cell(upper, column+1)
+	neighbors.LowerLeft = cell(upper+1, column-1)
+	neighbors.LowerRight = cell(upper+1, column+1)
+
+	return neighbors
+}
+
+func (grid hexGrid) createFloor(config *Dimensions, chars []string) [][]string {
+	data := createRows(config, (2*config.Width)+2)
+
+	for cell := 1; cell <= config.Length*config.Width; cell++ {
+		center := grid.getCenter(config, cell)
+
+		data[center[0]-1][center[1]] = chars[1]
+		data[center[0]+1][center[1]] = chars[1]
+		data[center[0]][center[1]-1] = "/"
+		data[center[0]][center[1]+1] = "\\"
+		data[center[0]+1][center[1]-1] = "\\"
+		data[center[0]+1][center[1]+1] = "/"
+	}
+
+	return data
+}
@@ -0,0 +1,232 @@
+package maze
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestGetTopology tests the functionality of getTopology
+func TestGetTopology(t *testing.T) {
+	Convey("TestGetTopology: Given a topology name", t, func() {
+		Convey("that is valid, the name should be returned without an error", func() {
+			for _, name := range []string{SquareTopology, HexTopology, TriangleTopology} {
+				grid, err := getTopology(name)
+
+				So(err, ShouldBeNil)
+				So(grid, ShouldEqual, name)
+			}
+		})
+
+		Convey("that is invalid, an error should be returned", func() {
+			_, err := getTopology("octagon")
+
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "Invalid topology found: octagon")
+		})
+	})
+}
+
+// TestHasSideMoves tests the functionality of HasSideMoves
+func TestHasSideMoves(t *testing.T) {
+	Convey("TestHasSideMoves: Given a topology name, only hexagonal cells should have side moves", t, func() {
+		So(HasSideMoves(HexTopology), ShouldBeTrue)
+		So(HasSideMoves(SquareTopology), ShouldBeFalse)
+		So(HasSideMoves(TriangleTopology), ShouldBeFalse)
+	})
+}
+
+// TestHexGrid tests the functionality of hexGrid
+func TestHexGrid(t *testing.T) {
+	Convey("TestHexGrid: Given a maze of 4 by 3 hexagonal cells", t, func() {
+		val := &Dimensions{Length: 4, Width: 3, Topology: HexTopology}
+
+		Convey("the cells in the even and the odd columns should have their side neighbors "+
+			"on different rows", func() {
+			So(val.getCellNeighbors(6), ShouldResemble, cellNeighbors{Top: 2, Bottom: 10, UpperLeft: 5,
+				UpperRight: 7, LowerLeft: 9, LowerRight: 11})
+			So(val.getCellNeighbors(7), ShouldResemble, cellNeighbors{Top: 3, Bottom: 11, UpperLeft: 2,
+				UpperRight: 4, LowerLeft: 6, LowerRight: 8})
+			So(val.getCellNeighbors(1), ShouldResemble, cellNeighbors{Bottom: 5, LowerRight: 2})
+		})
+
+		Convey("every cell should be found from its own address", func() {
+			for cell := 1; cell <= val.getCellsCount(); cell++ {
+				So(val.getCellNumber(val.getCellAddress(cell).MiddleCenter), ShouldEqual, cell)
+			}
+
+			So(val.getCellAddress(2).MiddleCenter, ShouldResemble, []int{2, 3})
+			So(val.getCellNumber([]int{1, 3}), ShouldEqual, 0)
+		})
+
+		Convey("the shared walls of the neighbors should be found at the same point", func() {
+			for cell := 1; cell <= val.getCellsCount(); cell++ {
+				for _, neighbor := range val.getNeighborCells(cell) {
+					wall, _ := val.getCommonWall(cell, neighbor)
+					back, _ := val.getCommonWall(neighbor, cell)
+
+					So(wall, ShouldResemble, back)
+				}
+			}
+		})
+	})
+}
+
+// TestTriangleGrid tests the functionality of triangleGrid
+func TestTriangleGrid(t *testing.T) {
+	Convey("TestTriangleGrid: Given a maze of 4 by 3 triangular cells", t, func() {
+		val := &Dimensions{Length: 4, Width: 3, Topology: TriangleTopology}
+
+		Convey("the cells pointing up should only have a neighbor below them", func() {
+			So(val.getCellNeighbors(6), ShouldResemble, cellNeighbors{Left: 5, Right: 7, Bottom: 10})
+			So(val.getCellNeighbors(7), ShouldResemble, cellNeighbors{Left: 6, Right: 8, Top: 3})
+		})
+
+		Convey("every cell should only have the three directions of its orientation", func() {
+			So(val.getDirections(6), ShouldResemble, []string{"RIGHT", "DOWN", "LEFT"})
+			So(val.getDirections(7), ShouldResemble, []string{"UP", "RIGHT", "LEFT"})
+		})
+
+		Convey("the starting point should be along the maze edges", func() {
+			for i := 0; i < 20; i++ {
+				cellNo := val.getStartPosition()
+
+				So(len(val.getNeighborCells(cellNo)), ShouldBeLessThan, len(val.getDirections(cellNo)))
+			}
+		})
+
+		Convey("the walls should be slanted towards the tip of every cell", func() {
+			data, err := val.createPlayingField(1)
+
+			So(err, ShouldBeNil)
+			So(data[3][:5], ShouldResemble, []string{"\\", "   ", "/", "   ", "\\"})
+			So(data[4][1], ShouldEqual, "   ")
+			So(data[4][3], ShouldEqual, "---")
+		})
+	})
+}
+
+// TestGenerateMazeTopologies tests the functionality of generateMaze on every topology
+func TestGenerateMazeTopologies(t *testing.T) {
+	Convey("TestGenerateMazeTopologies: Given the dimensions of a maze", t, func() {
+		for _, grid := range []string{HexTopology, TriangleTopology} {
+			val := &Dimensions{Length: 12, Width: 6, Topology: grid}
+			data, err := val.generateMaze(1, Difficulty{Braid: 0.5})
+
+			So(err, ShouldBeNil)
+			So(data, ShouldHaveLength, val.getFloorRows())
+
+			Convey("every cell of the "+grid+" topology should be reachable", func() {
+				So(val.getDistances(data, val.getCellNumber(val.StartPosition)), ShouldHaveLength, val.getCellsCount())
+			})
+
+			Convey("the wall follower should locate the target on the "+grid+" topology", func() {
+				opponent, err := newSeeker(WallFollower, val.getCellNumber(val.StartPosition))
+				So(err, ShouldBeNil)
+
+				// Without braiding the maze has no loops that could trap the wall follower.
+				data, err = val.generateMaze(1, Difficulty{})
+				So(err, ShouldBeNil)
+
+				opponent.position = val.getCellNumber(val.StartPosition)
+
+				for moves := 0; moves < 4*val.getCellsCount(); moves++ {
+					opponent.move(val, data)
+				}
+
+				So(opponent.position, ShouldEqual, val.getCellNumber(val.FinalPosition))
+			})
+
+			Convey("a maze of the "+grid+" topology with several floors should be generated", func() {
+				for _, floors := range []*Dimensions{
+					{Length: 5, Width: 4, Depth: 3, Topology: grid},
+					{Length: 11, Width: 6, Depth: 2, Topology: grid},
+				} {
+					data, err := floors.generateMaze(1, Difficulty{})
+
+					So(err, ShouldBeNil)
+					So(floors.getDistances(data, floors.getCellNumber(floors.StartPosition)), ShouldHaveLength,
+						floors.getCellsCount())
+				}
+			})
+		}
+	})
+}
+
+// TestPlayerMovementHex tests the functionality of playerMovement on hexagonal cells
+func TestPlayerMovementHex(t *testing.T) {
+	Convey("TestPlayerMovementHex: Given a maze of hexagonal cells", t, func() {
+		d := Dimensions{Length: 2, Width: 2, Topology: HexTopology}
+		data, err := d.createPlayingField(1)
+
+		So(err, ShouldBeNil)
+
+		d.createPath(data, 1, 2)
+		d.createPath(data, 3, 2)
+		d.StartPosition = d.getCellAddress(2).MiddleCenter
+
+		Convey("moving left should take the player to the side neighbor that can be reached", func() {
+			d.playerMovement(data, "LEFT")
+			So(d.getCellNumber(d.StartPosition), ShouldEqual, 1)
+
+			d.StartPosition = d.getCellAddress(2).MiddleCenter
+			d.playerMovement(data, "DOWNLEFT")
+			So(d.getCellNumber(d.StartPosition), ShouldEqual, 3)
+
+			d.playerMovement(data, "DOWN")
+			So(d.getCellNumber(d.StartPosition), ShouldEqual, 3)
+		})
+	})
+}
+
+// TestWrapAround tests the functionality of squareGrid on mazes whose edges wrap around
+func TestWrapAround(t *testing.T) {
+	Convey("TestWrapAround: Given a maze of 4 by 3 square cells that wraps around", t, func() {
+		val := &Dimensions{Length: 4, Width: 3, Wrap: true}
+
+		Convey("the cells along the edges should be neighbors of the cells on the opposite edges", func() {
+			So(val.getCellNeighbors(1), ShouldResemble, cellNeighbors{Top: 9, Bottom: 5, Left: 4, Right: 2})
+			So(val.getCellNeighbors(12), ShouldResemble, cellNeighbors{Top: 8, Bottom: 4, Left: 11, Right: 9})
+			So(val.getCellNeighbors(6), ShouldResemble, cellNeighbors{Top: 2, Bottom: 10, Left: 5, Right: 7})
+		})
+
+		Convey("edges with only two cells between them should not wrap around", func() {
+			narrow := &Dimensions{Length: 2, Width: 3, Wrap: true}
+			So(narrow.getCellNeighbors(1), ShouldResemble, cellNeighbors{Top: 5, Bottom: 3, Right: 2})
+		})
+
+		Convey("a path across the edges should open the walls on both edges", func() {
+			data, err := val.createPlayingField(1)
+			So(err, ShouldBeNil)
+
+			val.createPath(data, 4, 1)
+
+			So(data[1][0], ShouldEqual, " ")
+			So(data[1][8], ShouldEqual, " ")
+			So(val.getOpenNeighbors(data, 1), ShouldResemble, []int{4})
+			So(val.getOpenNeighbors(data, 4), ShouldResemble, []int{1})
+		})
+
+		Convey("the player should leave through one edge and enter through the other", func() {
+			data, err := val.createPlayingField(1)
+			So(err, ShouldBeNil)
+
+			val.createPath(data, 1, 9)
+			val.StartPosition = val.getCellAddress(1).MiddleCenter
+
+			val.playerMovement(data, "LEFT")
+			So(val.getCellNumber(val.StartPosition), ShouldEqual, 1)
+
+			val.playerMovement(data, "UP")
+			So(val.getCellNumber(val.StartPosition), ShouldEqual, 9)
+		})
+
+		Convey("every cell of a generated maze should be reachable", func() {
+			val = &Dimensions{Length: 12, Width: 6, Wrap: true}
+			data, err := val.generateMaze(1, Difficulty{Braid: 0.5})
+
+			So(err, ShouldBeNil)
+			So(val.getDistances(data, val.getCellNumber(val.StartPosition)), ShouldHaveLength, val.getCellsCount())
+		})
+	})
+}
@@ -0,0 +1,133 @@
+package maze
+
+import "math"
+
+const (
+	// horizontalBridge defines the content of a crossing cell where the corridor going left
+	// and right passes over a tunnel going up and down.
+	horizontalBridge = " = "
+
+	// verticalBridge defines the content of a crossing cell where the corridor going up
+	// and down passes over a tunnel going left and right.
+	verticalBridge = " H "
+
+	// weaveDensity defines the fraction of the cells that can become crossings that are
+	// turned into crossings in a weave maze.
+	weaveDensity = 0.3
+)
+
+// crossingGlyphs defines how the crossing cells are drawn on the terminal.
+var crossingGlyphs = map[string]string{
+	horizontalBridge: "═══",
+	verticalBridge:   " ║ ",
+}
+
+// isTunnel checks if moving in the provided direction through a cell with the provided
+// content passes under a bridge.
+func isTunnel(cell, direction string) bool {
+	switch cell {
+	case horizontalBridge:
+		return direction == "UP" || direction == "DOWN"
+
+	case verticalBridge:
+		return direction == "LEFT" || direction == "RIGHT"
+	}
+
+	return false
+}
+
+// getCellContent returns the content of the provided cell.
+func (config *Dimensions) getCellContent(maze [][]string, cellNo int) string {
+	pos := config.getCellAddress(cellNo).MiddleCenter
+	if len(pos) == 0 {
+		return ""
+	}
+	return maze[pos[0]][pos[1]]
+}
+
+// weaveMaze turns the provided fraction of the straight corridor cells into crossings where a
+// new tunnel passes under the corridor, joining the two cells on either side of it. The cells
+// joined cannot be crossings themselves so that a tunnel never passes under two bridges.
+func (config *Dimensions) weaveMaze(maze [][]string, fraction float64) {
+	var (
+		index   int
+		cellNo  int
+		content string
+
+		candidates = config.getCrossingCandidates(maze)
+	)
+
+	for count := int(math.Round(fraction * float64(len(candidates)))); count > 0 && len(candidates) > 0; {
+		index = getRandomNo(len(candidates))
+		cellNo = candidates[index]
+		candidates = append(candidates[:index], candidates[index+1:]...)
+
+		// The candidate might have changed after an earlier crossing was added.
+		if content = config.getCrossingType(maze, cellNo); content == "" {
+			continue
+		}
+
+		neighbors := config.getCellNeighbors(cellNo)
+
+		if content == horizontalBridge {
+			config.createPath(maze, cellNo, neighbors.Top)
+			config.createPath(maze, cellNo, neighbors.Bottom)
+		} else {
+			config.createPath(maze, cellNo, neighbors.Left)
+			config.createPath(maze, cellNo, neighbors.Right)
+		}
+
+		config.setCell(maze, cellNo, content)
+		count--
+	}
+}
+
+// getCrossingCandidates returns the cells that can be turned into crossings.
+func (config *Dimensions) getCrossingCandidates(maze [][]string) []int {
+	var candidates []int
+
+	for cell := 1; cell <= config.getCellsCount(); cell++ {
+		if config.getCrossingType(maze, cell) != "" {
+			candidates = append(candidates, cell)
+		}
+	}
+
+	return candidates
+}
+
+// getCrossingType returns the bridge that the provided cell can become. A cell can only become
+// a crossing if it is an empty straight corridor, other than the starting point, with both its
+// other neighbors present and neither of them nor the cell itself next to a crossing.
+// An empty string is returned if the cell cannot become a crossing.
+func (config *Dimensions) getCrossingType(maze [][]string, cellNo int) string {
+	var (
+		bridge    string
+		across    []int
+		neighbors = config.getCellNeighbors(cellNo)
+		openCells = config.getOpenNeighbors(maze, cellNo)
+	)
+
+	if config.getCellContent(maze, cellNo) != emptyCell || cellNo == config.getCellNumber(config.StartPosition) ||
+		len(openCells) != 2 {
+		return ""
+	}
+
+	switch {
+	case isCellFound(openCells, neighbors.Left) && isCellFound(openCells, neighbors.Right):
+		bridge, across = horizontalBridge, []int{neighbors.Top, neighbors.Bottom}
+
+	case isCellFound(openCells, neighbors.Top) && isCellFound(openCells, neighbors.Bottom):
+		bridge, across = verticalBridge, []int{neighbors.Left, neighbors.Right}
+
+	default:
+		return ""
+	}
+
+	for _, cell := range append(across, openCells...) {
+		if cell == 0 || crossingGlyphs[config.getCellContent(maze, cell)] != "" {
+			return ""
+		}
+	}
+
+	return bridge
+}
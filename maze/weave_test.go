@@ -0,0 +1,105 @@
+package maze
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// crossingMaze defines a 3 by 3 cells maze where cell 5 is a straight corridor
+// from left to right that can become a crossing.
+var crossingMaze = [][]string{
+	{"|", "---", "|", "---", "|", "---", "|"},
+	{"|", "   ", "|", "   ", "|", "   ", "|"},
+	{"|", "   ", "|", "---", "|", "   ", "|"},
+	{"|", "   ", " ", "   ", " ", "   ", "|"},
+	{"|", "---", "|", "---", "|", "---", "|"},
+	{"|", "   ", "|", "   ", "|", "   ", "|"},
+	{"|", "---", "|", "---", "|", "---", "|"},
+}
+
+// TestIsTunnel tests the functionality of isTunnel
+func TestIsTunnel(t *testing.T) {
+	Convey("TestIsTunnel: Given the cell content and a direction", t, func() {
+		Convey("that is a bridge, only the directions across the bridge should be tunnels", func() {
+			So(isTunnel(horizontalBridge, "UP"), ShouldBeTrue)
+			So(isTunnel(horizontalBridge, "DOWN"), ShouldBeTrue)
+			So(isTunnel(horizontalBridge, "LEFT"), ShouldBeFalse)
+			So(isTunnel(verticalBridge, "LEFT"), ShouldBeTrue)
+			So(isTunnel(verticalBridge, "RIGHT"), ShouldBeTrue)
+			So(isTunnel(verticalBridge, "DOWN"), ShouldBeFalse)
+		})
+
+		Convey("that is not a bridge, no direction should be a tunnel", func() {
+			for _, direction := range neighborDirections {
+				So(isTunnel(emptyCell, direction), ShouldBeFalse)
+				So(isTunnel(coinItem, direction), ShouldBeFalse)
+			}
+		})
+	})
+}
+
+// TestGetCrossingType tests the functionality of getCrossingType
+func TestGetCrossingType(t *testing.T) {
+	Convey("TestGetCrossingType: Given a cell", t, func() {
+		val := &Dimensions{Length: 3, Width: 3, StartPosition: []int{1, 1}}
+		data := copyMaze(crossingMaze)
+
+		Convey("that is a straight corridor with both other neighbors, a bridge should be returned", func() {
+			So(val.getCrossingType(data, 5), ShouldEqual, horizontalBridge)
+		})
+
+		Convey("that is not a straight corridor or is on the maze edge, an empty string should be returned", func() {
+			for _, cell := range []int{1, 2, 3, 4, 6, 7, 8, 9} {
+				So(val.getCrossingType(data, cell), ShouldBeEmpty)
+			}
+		})
+
+		Convey("that already has an item, an empty string should be returned", func() {
+			val.setCell(data, 5, coinItem)
+			So(val.getCrossingType(data, 5), ShouldBeEmpty)
+		})
+	})
+}
+
+// TestWeaveMaze tests the functionality of weaveMaze
+func TestWeaveMaze(t *testing.T) {
+	Convey("TestWeaveMaze: Given a maze with a straight corridor", t, func() {
+		val := &Dimensions{Length: 3, Width: 3, StartPosition: []int{1, 1}}
+		data := copyMaze(crossingMaze)
+
+		val.weaveMaze(data, 1)
+
+		Convey("the corridor should become a bridge over a new tunnel", func() {
+			So(val.getCellContent(data, 5), ShouldEqual, horizontalBridge)
+			So(val.getOpenNeighbors(data, 2), ShouldResemble, []int{8})
+			So(val.getOpenNeighbors(data, 8), ShouldResemble, []int{2})
+			So(val.getOpenNeighbors(data, 5), ShouldResemble, []int{4, 6})
+			So(val.getOpenNeighbors(data, 4), ShouldContain, 5)
+		})
+	})
+
+	Convey("TestWeaveMaze: Given a generated maze", t, func() {
+		val := &Dimensions{Length: 20, Width: 16}
+		data, err := val.generateMaze(1, Difficulty{})
+
+		So(err, ShouldBeNil)
+
+		candidates := len(val.getCrossingCandidates(data))
+		val.weaveMaze(data, weaveDensity)
+
+		Convey("crossings should be added and every cell should still be reachable", func() {
+			crossings := 0
+
+			for cell := 1; cell <= val.Length*val.Width; cell++ {
+				if crossingGlyphs[val.getCellContent(data, cell)] != "" {
+					crossings++
+				}
+			}
+
+			So(crossings, ShouldBeGreaterThan, 0)
+			So(crossings, ShouldBeLessThanOrEqualTo, candidates)
+			So(val.getDistances(data, val.getCellNumber(val.StartPosition)), ShouldHaveLength, val.Length*val.Width)
+		})
+	})
+}
@@ -0,0 +1,266 @@
+// Package profile stores the named player profiles and their lifetime statistics on the
+// local disk.
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/dmigwi/tapoo/game"
+)
+
+// maxNameLength defines the longest player name allowed, in characters.
+const maxNameLength = 20
+
+// Level defines the best results of a player on a single level. BestTime holds the fewest
+// seconds taken to win the level.
+type Level struct {
+	BestScore int `json:"bestScore"`
+	BestTime  int `json:"bestTime"`
+}
+
+// Profile defines a player and the statistics of every level they have played. Losses
+// count the levels lost on time together with the ones where a computer seeker won.
+type Profile struct {
+	Name            string        `json:"name"`
+	LevelsCompleted int           `json:"levelsCompleted"`
+	Losses          int           `json:"losses"`
+	Moves           int           `json:"moves"`
+	HintsUsed       int           `json:"hintsUsed"`
+	Levels          map[int]Level `json:"levels"`
+}
+
+// WinLossRatio returns the number of levels completed for every level lost. If no level
+// has been lost the number of levels completed is returned.
+func (p Profile) WinLossRatio() float64 {
+	if p.Losses == 0 {
+		return float64(p.LevelsCompleted)
+	}
+	return float64(p.LevelsCompleted) / float64(p.Losses)
+}
+
+// BestScores returns the best score of every level won by the player.
+func (p Profile) BestScores() map[int]int {
+	scores := make(map[int]int, len(p.Levels))
+
+	for level, stats := range p.Levels {
+		scores[level] = stats.BestScore
+	}
+
+	return scores
+}
+
+// record updates the statistics of the player with the provided level result.
+func (p *Profile) record(result game.Result) {
+	p.Moves += result.Moves
+	p.HintsUsed += result.HintsUsed
+
+	if result.State != game.Won {
+		p.Losses++
+		return
+	}
+
+	p.LevelsCompleted++
+
+	if p.Levels == nil {
+		p.Levels = map[int]Level{}
+	}
+
+	stats, played := p.Levels[result.Level]
+
+	if result.Scores > stats.BestScore {
+		stats.BestScore = result.Scores
+	}
+
+	if !played || result.Seconds < stats.BestTime {
+		stats.BestTime = result.Seconds
+	}
+
+	p.Levels[result.Level] = stats
+}
+
+// Store defines the profiles saved in a file. Current holds the name of the player who
+// played last, so that the name is only asked once. A store can be shared by several games.
+type Store struct {
+	path string
+
+	mu       sync.Mutex
+	current  string
+	profiles map[string]*Profile
+}
+
+// storeFile defines the content of the file holding the profiles.
+type storeFile struct {
+	Current  string              `json:"current"`
+	Profiles map[string]*Profile `json:"profiles"`
+}
+
+// DefaultPath returns the path of the profiles file in the configuration directory of the user.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "tapoo", "profiles.json"), nil
+}
+
+// Open returns the store of the profiles saved in the provided file. A file that does not
+// exist yet holds no profiles, it is created once the store is saved.
+func Open(path string) (*Store, error) {
+	content := storeFile{Profiles: map[string]*Profile{}}
+
+	data, err := os.ReadFile(path)
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+
+	case err != nil:
+		return nil, err
+
+	default:
+		if err := json.Unmarshal(data, &content); err != nil {
+			return nil, fmt.Errorf("Invalid profiles file found: %s. %v", path, err)
+		}
+	}
+
+	if content.Profiles == nil {
+		content.Profiles = map[string]*Profile{}
+	}
+
+	return &Store{path: path, current: content.Current, profiles: content.Profiles}, nil
+}
+
+// CheckName returns the provided player name without its surrounding spaces. If the name
+// is empty or too long an error is thrown.
+func CheckName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return "", fmt.Errorf("Invalid player name found: %q. Allowed 1 to %d characters", name, maxNameLength)
+	}
+
+	return name, nil
+}
+
+// Current returns the name of the player who played last, it is empty if no one has played yet.
+func (s *Store) Current() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.current
+}
+
+// SetCurrent selects the player with the provided name, creating their profile if needed,
+// and saves the store. If the name is invalid an error is thrown.
+func (s *Store) SetCurrent(name string) error {
+	name, err := CheckName(name)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.current = name
+	s.getProfile(name)
+
+	return s.save()
+}
+
+// Profile returns a copy of the profile of the player with the provided name. A player
+// without a profile has no statistics.
+func (s *Store) Profile(name string) Profile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.profiles[name]; ok {
+		return copyProfile(p)
+	}
+
+	return Profile{Name: name, Levels: map[int]Level{}}
+}
+
+// Profiles returns a copy of every profile sorted by the player names.
+func (s *Store) Profiles() []Profile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profiles := make([]Profile, 0, len(s.profiles))
+
+	for _, p := range s.profiles {
+		profiles = append(profiles, copyProfile(p))
+	}
+
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+
+	return profiles
+}
+
+// Record adds the provided level result to the profile of its player and saves the store.
+// Results without a valid player name are rejected with an error.
+func (s *Store) Record(result game.Result) error {
+	name, err := CheckName(result.Player)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.getProfile(name).record(result)
+
+	return s.save()
+}
+
+// getProfile returns the profile of the player with the provided name, an empty profile
+// is added if the player has none.
+func (s *Store) getProfile(name string) *Profile {
+	p, ok := s.profiles[name]
+	if !ok {
+		p = &Profile{Name: name}
+		s.profiles[name] = p
+	}
+
+	return p
+}
+
+// copyProfile returns a copy of the provided profile that does not share its levels.
+func copyProfile(p *Profile) Profile {
+	profile := *p
+	profile.Levels = make(map[int]Level, len(p.Levels))
+
+	for level, stats := range p.Levels {
+		profile.Levels[level] = stats
+	}
+
+	return profile
+}
+
+// save writes the profiles to the store file. The file is replaced in a single step so
+// that a failed write never loses the profiles saved before.
+func (s *Store) save() error {
+	data, err := json.MarshalIndent(storeFile{Current: s.current, Profiles: s.profiles}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+
+	temp := s.path + ".tmp"
+	if err := os.WriteFile(temp, data, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(temp, s.path)
+}
@@ -0,0 +1,109 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dmigwi/tapoo/game"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestOpen tests the functionality of Open
+func TestOpen(t *testing.T) {
+	Convey("TestOpen: Given the path of a profiles file", t, func() {
+		path := filepath.Join(t.TempDir(), "tapoo", "profiles.json")
+
+		Convey("that does not exist yet, an empty store should be returned", func() {
+			s, err := Open(path)
+
+			So(err, ShouldBeNil)
+			So(s.Current(), ShouldBeEmpty)
+			So(s.Profiles(), ShouldBeEmpty)
+		})
+
+		Convey("that was saved before, its profiles should be read back", func() {
+			s, err := Open(path)
+			So(err, ShouldBeNil)
+
+			So(s.SetCurrent("  Ann "), ShouldBeNil)
+			So(s.Record(game.Result{Player: "Ann", Level: 2, State: game.Won, Seconds: 40, Scores: 900}), ShouldBeNil)
+
+			s, err = Open(path)
+
+			So(err, ShouldBeNil)
+			So(s.Current(), ShouldEqual, "Ann")
+			So(s.Profile("Ann").Levels, ShouldResemble, map[int]Level{2: {BestScore: 900, BestTime: 40}})
+		})
+
+		Convey("that holds something else, an error should be returned", func() {
+			So(os.MkdirAll(filepath.Dir(path), 0o755), ShouldBeNil)
+			So(os.WriteFile(path, []byte("tapoo"), 0o644), ShouldBeNil)
+
+			_, err := Open(path)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+// TestCheckName tests the functionality of CheckName
+func TestCheckName(t *testing.T) {
+	Convey("TestCheckName: Given a player name", t, func() {
+		Convey("that is valid, it should be returned without its surrounding spaces", func() {
+			name, err := CheckName(" Migwi ")
+
+			So(err, ShouldBeNil)
+			So(name, ShouldEqual, "Migwi")
+		})
+
+		Convey("that is empty or too long, an error should be returned", func() {
+			_, err := CheckName("   ")
+			So(err, ShouldNotBeNil)
+
+			_, err = CheckName("a name that is far too long to fit")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+// TestRecord tests the functionality of Record
+func TestRecord(t *testing.T) {
+	Convey("TestRecord: Given the results of several levels", t, func() {
+		s, err := Open(filepath.Join(t.TempDir(), "profiles.json"))
+		So(err, ShouldBeNil)
+
+		results := []game.Result{
+			{Player: "Ann", Level: 1, State: game.Won, Seconds: 50, Scores: 700, Moves: 30, HintsUsed: 1},
+			{Player: "Ann", Level: 1, State: game.Won, Seconds: 35, Scores: 650, Moves: 20},
+			{Player: "Ann", Level: 2, State: game.Lost, Moves: 60, HintsUsed: 2},
+			{Player: "Ann", Level: 2, State: game.Beaten, Moves: 10},
+			{Player: "Bob", Level: 1, State: game.Won, Seconds: 70, Scores: 400, Moves: 45},
+		}
+
+		for _, result := range results {
+			So(s.Record(result), ShouldBeNil)
+		}
+
+		Convey("the lifetime stats of every player should add up", func() {
+			ann := s.Profile("Ann")
+
+			So(ann.LevelsCompleted, ShouldEqual, 2)
+			So(ann.Losses, ShouldEqual, 2)
+			So(ann.WinLossRatio(), ShouldEqual, 1)
+			So(ann.Moves, ShouldEqual, 120)
+			So(ann.HintsUsed, ShouldEqual, 3)
+			So(ann.BestScores(), ShouldResemble, map[int]int{1: 700})
+
+			So(s.Profile("Bob").WinLossRatio(), ShouldEqual, 1)
+			So(s.Profiles(), ShouldHaveLength, 2)
+		})
+
+		Convey("the best score and the best time should be kept for every level", func() {
+			So(s.Profile("Ann").Levels[1], ShouldResemble, Level{BestScore: 700, BestTime: 35})
+		})
+
+		Convey("without a player name, the result should be rejected", func() {
+			So(s.Record(game.Result{Level: 1, State: game.Won}), ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,81 @@
+package profile
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+// formatSeconds returns the provided seconds written as minutes and seconds, e.g. 1:05.
+func formatSeconds(seconds int) string {
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// getTopScore returns the highest score the player has reached on any level.
+func (p Profile) getTopScore() int {
+	top := 0
+
+	for _, stats := range p.Levels {
+		if stats.BestScore > top {
+			top = stats.BestScore
+		}
+	}
+
+	return top
+}
+
+// WriteStats writes the stats screen of the provided player, the lifetime totals followed by
+// the best score and the best time of every level won.
+func WriteStats(w io.Writer, p Profile) error {
+	var levels []int
+
+	for level := range p.Levels {
+		levels = append(levels, level)
+	}
+
+	sort.Ints(levels)
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(table, "PLAYER\t%s\n", p.Name)
+	fmt.Fprintf(table, "LEVELS COMPLETED\t%d\n", p.LevelsCompleted)
+	fmt.Fprintf(table, "LEVELS LOST\t%d\n", p.Losses)
+	fmt.Fprintf(table, "WIN/LOSS RATIO\t%.2f\n", p.WinLossRatio())
+	fmt.Fprintf(table, "TOTAL MOVES\t%d\n", p.Moves)
+	fmt.Fprintf(table, "HINTS USED\t%d\n", p.HintsUsed)
+
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	if len(levels) == 0 {
+		return nil
+	}
+
+	table = tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "\nLEVEL\tBEST SCORE\tBEST TIME\t")
+
+	for _, level := range levels {
+		fmt.Fprintf(table, "%d\t%d\t%s\t\n", level, p.Levels[level].BestScore, formatSeconds(p.Levels[level].BestTime))
+	}
+
+	return table.Flush()
+}
+
+// WriteHighScores writes the high score table ranking the provided players by the highest
+// score they have reached on any level.
+func WriteHighScores(w io.Writer, profiles []Profile) error {
+	ranked := append([]Profile{}, profiles...)
+
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].getTopScore() > ranked[j].getTopScore() })
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "RANK\tPLAYER\tHIGH SCORE\tLEVELS COMPLETED\tWIN/LOSS RATIO\t")
+
+	for index, p := range ranked {
+		fmt.Fprintf(table, "%d\t%s\t%d\t%d\t%.2f\t\n", index+1, p.Name, p.getTopScore(), p.LevelsCompleted, p.WinLossRatio())
+	}
+
+	return table.Flush()
+}
@@ -0,0 +1,46 @@
+package profile
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestWriteStats tests the functionality of WriteStats
+func TestWriteStats(t *testing.T) {
+	Convey("TestWriteStats: Given a player profile, its totals and its levels should be written", t, func() {
+		var output bytes.Buffer
+
+		err := WriteStats(&output, Profile{
+			Name:            "Ann",
+			LevelsCompleted: 3,
+			Losses:          2,
+			Moves:           140,
+			HintsUsed:       4,
+			Levels:          map[int]Level{2: {BestScore: 900, BestTime: 65}, 1: {BestScore: 1200, BestTime: 30}},
+		})
+
+		So(err, ShouldBeNil)
+		So(output.String(), ShouldContainSubstring, "WIN/LOSS RATIO    1.50")
+		So(output.String(), ShouldContainSubstring, "TOTAL MOVES       140")
+		So(output.String(), ShouldContainSubstring, "1        1200       0:30")
+		So(output.String(), ShouldContainSubstring, "2         900       1:05")
+	})
+}
+
+// TestWriteHighScores tests the functionality of WriteHighScores
+func TestWriteHighScores(t *testing.T) {
+	Convey("TestWriteHighScores: Given several players, they should be ranked by their high scores", t, func() {
+		var output bytes.Buffer
+
+		err := WriteHighScores(&output, []Profile{
+			{Name: "Ann", Levels: map[int]Level{1: {BestScore: 700}}},
+			{Name: "Bob", Levels: map[int]Level{1: {BestScore: 400}, 2: {BestScore: 1500}}},
+		})
+
+		So(err, ShouldBeNil)
+		So(output.String(), ShouldContainSubstring, "1     Bob        1500")
+		So(output.String(), ShouldContainSubstring, "2     Ann         700")
+	})
+}
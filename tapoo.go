@@ -1,8 +1,407 @@
 package main
 
-import "github.com/dmigwi/tapoo/maze"
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dmigwi/tapoo/game"
+	"github.com/dmigwi/tapoo/maze"
+	"github.com/dmigwi/tapoo/profile"
+	"github.com/dmigwi/tapoo/terminal"
+	"github.com/dmigwi/tapoo/web"
+)
+
+var (
+	// difficulty defines the game preset used to create the maze levels.
+	difficulty = flag.String("difficulty", "normal", "game difficulty: easy, normal, hard or insane")
+
+	// target defines the strategy used to place the target in the maze.
+	target = flag.String("target", maze.FarthestTarget, "target placement: farthest, deadend, distance or chosen")
+
+	// minDistance defines the minimum distance to the target used by the distance placement.
+	minDistance = flag.Int("distance", 0, "minimum moves to the target when using the distance placement")
+
+	// moving defines whether the target moves through the maze hiding from the player.
+	moving = flag.Bool("moving", false, "the target moves through the maze hiding from the player")
+
+	// seekers defines the strategies of the computer seekers racing the player to the target.
+	seekers = flag.String("seekers", "", "comma separated computer seekers: wallfollower, tremaux, bfs or random")
+
+	// items defines whether coins, time bonuses, keys and doors are placed in the maze.
+	items = flag.Bool("items", false, "place coins, time bonuses, keys and locked doors in the maze")
+
+	// teleporters defines whether paired teleporters are placed in the maze.
+	teleporters = flag.Bool("teleporters", false, "place paired teleporters in the maze")
+
+	// oneWay defines whether one-way gates are placed in the maze.
+	oneWay = flag.Bool("oneway", false, "place one-way gates in the maze")
+
+	// weave defines whether corridors can pass over and under each other.
+	weave = flag.Bool("weave", false, "create a weave maze where corridors pass over and under each other")
+
+	// floors defines the number of maze floors joined by stairs.
+	floors = flag.Int("floors", 1, "number of maze floors joined by stairs")
+
+	// shape defines the shape of the maze, either a built-in shape or a mask file.
+	shape = flag.String("shape", "", "maze shape: circle, diamond, heart, letters[:TEXT] or a mask file path")
+
+	// topology defines the shape of the maze cells.
+	topology = flag.String("topology", maze.SquareTopology, "shape of the maze cells: square, hex or triangle")
+
+	// wrap defines whether the opposite maze edges are joined together.
+	wrap = flag.Bool("wrap", false, "join the opposite maze edges so that moving off one edge leads to the other")
+
+	// aspect defines the ratio of the maze length to its width.
+	aspect = flag.Float64("aspect", 0, "ratio of the maze length to its width, 0 uses the terminal aspect ratio")
+
+	// player defines the name of the player, the player who played last is used if it is empty.
+	player = flag.String("player", "", "name of the player, the last player is used if empty")
+
+	// profiles defines the path of the file holding the player profiles.
+	profiles = flag.String("profiles", "", "path of the player profiles file, the user configuration directory if empty")
+)
 
 // Main defines where the program executions starts
 func main() {
-	maze.Start()
+	flag.Parse()
+
+	switch flag.Arg(0) {
+	case "analyze":
+		analyze(flag.Args()[1:])
+		return
+
+	case "export":
+		export(flag.Args()[1:])
+		return
+
+	case "booklet":
+		booklet(flag.Args()[1:])
+		return
+
+	case "web":
+		serve(flag.Args()[1:])
+		return
+
+	case "stats":
+		stats()
+		return
+	}
+
+	var (
+		store     = openProfiles()
+		name      = getPlayer(store)
+		opts      = getOptions()
+		recordErr error
+	)
+
+	opts.Player = name
+	opts.BestScores = store.Profile(name).BestScores()
+	opts.Record = func(result game.Result) {
+		if err := store.Record(result); err != nil {
+			recordErr = err
+		}
+	}
+
+	terminal.Start(opts)
+
+	if recordErr != nil {
+		fmt.Println(recordErr)
+		os.Exit(1)
+	}
+}
+
+// exitOnError prints the provided error and exits, nothing happens if there is no error.
+func exitOnError(err error) {
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// openProfiles returns the store of the player profiles selected by the flags.
+func openProfiles() *profile.Store {
+	path := *profiles
+
+	if path == "" {
+		var err error
+
+		path, err = profile.DefaultPath()
+		exitOnError(err)
+	}
+
+	store, err := profile.Open(path)
+	exitOnError(err)
+
+	return store
+}
+
+// getPlayer returns the name of the player selected by the flags or the player who played
+// last. The name is only asked the first time the game is played.
+func getPlayer(store *profile.Store) string {
+	name := *player
+	if name == "" {
+		name = store.Current()
+	}
+
+	input := bufio.NewScanner(os.Stdin)
+
+	for {
+		if name == "" {
+			fmt.Print("Enter your player name: ")
+
+			if !input.Scan() {
+				exitOnError(fmt.Errorf("No player name found. Allowed any name entered or -player NAME"))
+			}
+
+			name = input.Text()
+		}
+
+		err := store.SetCurrent(name)
+		if err == nil {
+			return store.Current()
+		}
+
+		fmt.Println(err)
+		name = ""
+	}
+}
+
+// stats prints the stats screen of the player selected by the flags, or the player who played
+// last, followed by the high score table of every player.
+func stats() {
+	store := openProfiles()
+
+	name := *player
+	if name == "" {
+		name = store.Current()
+	}
+
+	if name != "" {
+		exitOnError(profile.WriteStats(os.Stdout, store.Profile(name)))
+		fmt.Println()
+	}
+
+	exitOnError(profile.WriteHighScores(os.Stdout, store.Profiles()))
+}
+
+// getOptions returns the game options selected by the command line flags.
+func getOptions() game.Options {
+	return game.Options{
+		Difficulty:   *difficulty,
+		Target:       *target,
+		MinDistance:  *minDistance,
+		MovingTarget: *moving,
+		Seekers:      strings.FieldsFunc(*seekers, func(r rune) bool { return r == ',' }),
+		Items:        *items,
+		Teleporters:  *teleporters,
+		OneWayGates:  *oneWay,
+		Weave:        *weave,
+		Floors:       *floors,
+		Shape:        *shape,
+		Topology:     *topology,
+		Wrap:         *wrap,
+		AspectRatio:  *aspect,
+	}
+}
+
+// serve plays the game in the browser, using the game options selected by the flags
+// written before the web command.
+func serve(args []string) {
+	set := flag.NewFlagSet("web", flag.ExitOnError)
+
+	// listen defines the address where the web front end is served.
+	listen := set.String("listen", ":8080", "address where the web front end is served")
+
+	set.Parse(args)
+
+	if err := web.Serve(*listen, getOptions(), openProfiles()); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// analyze generates many mazes for every maze generator, difficulty preset and size
+// requested and prints a table of their average quality metrics.
+func analyze(args []string) {
+	set := flag.NewFlagSet("analyze", flag.ExitOnError)
+
+	var (
+		// generators defines the maze generation algorithms compared.
+		generators = set.String("generators", "", "comma separated maze generators: dfs (depth-first search) "+
+			"or braided (depth-first search with half the dead ends removed)")
+
+		// presets defines the difficulty presets whose maze generator settings are compared.
+		presets = set.String("presets", "", "comma separated difficulty presets; all generators and presets if "+
+			"neither is set")
+
+		// sizes defines the sizes of the mazes generated.
+		sizes = set.String("sizes", "10x10,20x10,40x20", "comma separated maze sizes written as LENGTHxWIDTH")
+
+		// count defines the number of mazes generated for every generator, preset and size.
+		count = set.Int("count", 20, "number of mazes generated for every generator, preset and size")
+
+		// topology defines the shape of the maze cells.
+		topology = set.String("topology", maze.SquareTopology, "shape of the maze cells: square, hex or triangle")
+
+		split = func(r rune) bool { return r == ',' }
+	)
+
+	set.Parse(args)
+
+	err := maze.Analyze(maze.AnalyzeOptions{
+		Generators: strings.FieldsFunc(*generators, split),
+		Presets:    strings.FieldsFunc(*presets, split),
+		Sizes:      strings.FieldsFunc(*sizes, split),
+		Count:      *count,
+		Topology:   *topology,
+	}, os.Stdout)
+
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// export generates or loads a maze and writes it to an image file that can be printed.
+func export(args []string) {
+	set := flag.NewFlagSet("export", flag.ExitOnError)
+
+	var (
+		// format defines the format of the image written.
+		format = set.String("format", maze.SVGFormat, "image format: svg or png")
+
+		// output defines the path of the image file, maze.FORMAT is used if it is empty.
+		output = set.String("output", "", "path of the image file, maze.svg or maze.png if empty")
+
+		// input defines the path of a maze saved as text, a new maze is generated if it is empty.
+		input = set.String("input", "", "path of a maze saved as text, a new maze is generated if empty")
+
+		// length and width define the number of cells along the maze edges.
+		length = set.Int("length", 20, "number of cells along the horizontal maze edges")
+		width  = set.Int("width", 10, "number of cells along the vertical maze edges")
+
+		// intensity defines how thick the maze walls are drawn.
+		intensity = set.Int("intensity", 1, "thickness of the maze walls: 1, 2 or 3")
+
+		// cellSize defines the width of a square cell in pixels.
+		cellSize = set.Int("cellsize", 32, "width of a square cell in pixels")
+
+		// solution defines whether the shortest path to the target is drawn.
+		solution = set.Bool("solution", false, "draw the shortest path from the start to the target")
+
+		// markers defines whether the start, the target and the stairs are drawn.
+		markers = set.Bool("markers", true, "draw the start, the target and the stairs")
+
+		difficulty = set.String("difficulty", "normal", "game difficulty: easy, normal, hard or insane")
+		target     = set.String("target", maze.FarthestTarget, "target placement: farthest, deadend or distance")
+		floors     = set.Int("floors", 1, "number of maze floors joined by stairs")
+		shape      = set.String("shape", "", "maze shape: circle, diamond, heart, letters[:TEXT] or a mask file path")
+		topology   = set.String("topology", maze.SquareTopology, "shape of the maze cells: square, hex or triangle")
+		wrap       = set.Bool("wrap", false, "join the opposite maze edges")
+	)
+
+	set.Parse(args)
+
+	if *output == "" {
+		*output = "maze." + *format
+	}
+
+	m, err := loadMaze(*input, maze.Config{
+		Length:     *length,
+		Width:      *width,
+		Floors:     *floors,
+		Topology:   *topology,
+		Wrap:       *wrap,
+		Shape:      *shape,
+		Difficulty: *difficulty,
+		Intensity:  *intensity,
+		Target:     *target,
+	})
+
+	// The image is only written to the file once it is drawn successfully.
+	var image bytes.Buffer
+
+	if err == nil {
+		err = m.Export(&image, maze.ExportOptions{
+			Format:   *format,
+			CellSize: *cellSize,
+			Solution: *solution,
+			Markers:  *markers,
+		})
+	}
+
+	if err == nil {
+		err = os.WriteFile(*output, image.Bytes(), 0644)
+	}
+
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// loadMaze reads the maze saved as text in the provided file. If no file is provided a new
+// maze is generated using the provided settings.
+func loadMaze(path string, config maze.Config) (*maze.Maze, error) {
+	if path == "" {
+		return maze.NewMaze(config)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	return maze.LoadMaze(file)
+}
+
+// booklet writes a printable PDF booklet of mazes at increasing levels with an answer key.
+func booklet(args []string) {
+	set := flag.NewFlagSet("booklet", flag.ExitOnError)
+
+	var (
+		// output defines the path of the PDF file.
+		output = set.String("output", "booklet.pdf", "path of the PDF file")
+
+		// title defines the text written on top of every page.
+		title = set.String("title", "", "title written on top of every page")
+
+		// first and levels define the levels of the mazes in the booklet.
+		first  = set.Int("first", 1, "level of the maze on the first page")
+		levels = set.Int("levels", 10, "number of mazes in the booklet, one per page")
+
+		difficulty = set.String("difficulty", "normal", "game difficulty: easy, normal, hard or insane")
+		topology   = set.String("topology", maze.SquareTopology, "shape of the maze cells: square, hex or triangle")
+		shape      = set.String("shape", "", "maze shape: circle, diamond, heart, letters[:TEXT] or a mask file path")
+		intensity  = set.Int("intensity", 1, "thickness of the maze walls: 1, 2 or 3")
+
+		document bytes.Buffer
+	)
+
+	set.Parse(args)
+
+	err := maze.Booklet(&document, maze.BookletOptions{
+		Title:      *title,
+		Difficulty: *difficulty,
+		FirstLevel: *first,
+		Levels:     *levels,
+		Topology:   *topology,
+		Shape:      *shape,
+		Intensity:  *intensity,
+	})
+
+	if err == nil {
+		err = os.WriteFile(*output, document.Bytes(), 0644)
+	}
+
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 }
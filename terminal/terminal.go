@@ -0,0 +1,456 @@
+package terminal
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/dmigwi/tapoo/game"
+	"github.com/dmigwi/tapoo/maze"
+	termbox "github.com/nsf/termbox-go"
+)
+
+const (
+	intro            = "   You are playing the Maze runner, hide and seek game (Tapoo).      "
+	website          = " Visit https://www.linkedin.com/in/migwi-ndungu/ to contact the developer.  "
+	playerNavigation = "  Arrow Keys move the player (in Blue), H shows a hint, B drops a breadcrumb, Space pauses  "
+	hideMsg          = "  Move the target (in Red) with the Arrow Keys and Press Enter to hide it  "
+	hudMsg           = "   Level %d     Moves: %d     Hints: %d     Scores: %d     Best: %s   "
+	timeMsg          = "   Time %d:%02d  "
+	floorMsg         = "   Floor %d of %d.   Press PgUp or PgDn on the stairs to change floors   "
+
+	space              = "                                                                         "
+	pauseMsg           = "           Game Paused !!!     Press Space or Ctrl+P to resume           "
+	gameOverSucceed    = "    Game Over! : Congratulations, Won by Locating the target on time.    "
+	gameOverFailed     = "      Game Over! : Ooops!!!, Failed to locate the target on time.        "
+	gameOverBeaten     = "    Game Over! : Ooops!!!, A computer seeker located the target first.   "
+	gameOverNavigation = "        Press ESC or Ctrl+C to quit.     Press Ctrl+P to Proceed         "
+	proceedButton      = "[ Proceed ]"
+	quitButton         = "[ Quit ]"
+	highScores         = "                   High Scores: %d                             "
+	playerScores       = "           %s:   Scores: %d     Best Score of the Level: %d           "
+	playerMsg          = "   Player: %s  "
+)
+
+// minBarSize and maxBarSize define the limits of the number of characters of the time left
+// progress bar.
+const minBarSize, maxBarSize = 10, 40
+
+// coldef maintains the original color used on the
+// background or the foreground depending on its usage.
+const coldef = termbox.ColorDefault
+
+// directionKeys defines the keys used to move the player in every direction.
+var directionKeys = map[termbox.Key]string{
+	termbox.KeyArrowLeft:  "LEFT",
+	termbox.KeyArrowRight: "RIGHT",
+	termbox.KeyArrowUp:    "UP",
+	termbox.KeyArrowDown:  "DOWN",
+	termbox.KeyPgup:       "ABOVE",
+	termbox.KeyPgdn:       "BELOW",
+}
+
+// diagonalKeys defines the keys used to move to the upper and lower side neighbors of
+// a hexagonal cell. They are only mapped if the topology played has side moves.
+var diagonalKeys = map[rune]string{
+	'q': "UPLEFT",
+	'e': "UPRIGHT",
+	'a': "DOWNLEFT",
+	'd': "DOWNRIGHT",
+}
+
+// interruptions defines the message and the color displayed when the game stops.
+var interruptions = map[game.State]struct {
+	msg   string
+	color termbox.Attribute
+}{
+	game.Paused: {pauseMsg, termbox.ColorYellow},
+	game.Won:    {gameOverSucceed, termbox.ColorCyan},
+	game.Lost:   {gameOverFailed, termbox.ColorRed},
+	game.Beaten: {gameOverBeaten, termbox.ColorRed},
+}
+
+// button defines the player action selected by clicking on the text drawn from the position x
+// on the row y of the terminal.
+type button struct {
+	x, y   int
+	text   string
+	action game.Action
+}
+
+// contains checks if the provided terminal position is on the button.
+func (b button) contains(x, y int) bool {
+	return y == b.y && x >= b.x && x < b.x+len(b.text)
+}
+
+// terminal defines the frontend that plays the game on the terminal using termbox. Buttons
+// holds the buttons displayed when the game stops, they are drawn and clicked on separate goroutines.
+// Diagonals holds the keys mapped to the side moves of the topology played, if any.
+type terminal struct {
+	mu        sync.Mutex
+	buttons   []button
+	diagonals map[rune]string
+}
+
+// newTerminal prepares the terminal to play the game on a maze of the provided topology.
+func newTerminal(topology string) (*terminal, error) {
+	if err := termbox.Init(); err != nil {
+		return nil, err
+	}
+
+	termbox.SetInputMode(termbox.InputEsc | termbox.InputMouse)
+
+	t := &terminal{}
+	if maze.HasSideMoves(topology) {
+		t.diagonals = diagonalKeys
+	}
+
+	return t, nil
+}
+
+// Close restores the terminal.
+func (*terminal) Close() {
+	termbox.Close()
+}
+
+// Size returns the number of columns and rows of the terminal.
+func (*terminal) Size() (columns, rows int) {
+	return termbox.Size()
+}
+
+// getClickEvent returns the player action selected by a click on the provided terminal position.
+// Clicks on the buttons displayed select their actions while other clicks travel to the cell clicked.
+func (t *terminal) getClickEvent(x, y int) game.Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, b := range t.buttons {
+		if b.contains(x, y) {
+			return game.Event{Action: b.action}
+		}
+	}
+
+	return game.Event{Action: game.Travel, Row: y - 7, X: x - 3}
+}
+
+// Poll waits for a key mapped to a player action to be pressed or for a left click.
+func (t *terminal) Poll() (game.Event, error) {
+	for {
+		ev := termbox.PollEvent()
+
+		switch {
+		case ev.Type == termbox.EventError:
+			return game.Event{}, ev.Err
+
+		case ev.Type == termbox.EventMouse && ev.Key == termbox.MouseLeft:
+			return t.getClickEvent(ev.MouseX, ev.MouseY), nil
+
+		case ev.Type != termbox.EventKey:
+
+		case ev.Ch == 'h' || ev.Ch == 'H':
+			return game.Event{Action: game.Hint}, nil
+
+		case ev.Ch == 'b' || ev.Ch == 'B':
+			return game.Event{Action: game.Drop}, nil
+
+		case ev.Key == termbox.KeyEsc, ev.Key == termbox.KeyCtrlC:
+			return game.Event{Action: game.Quit}, nil
+
+		case ev.Key == termbox.KeyCtrlP:
+			return game.Event{Action: game.Proceed}, nil
+
+		case ev.Key == termbox.KeySpace:
+			return game.Event{Action: game.Pause}, nil
+
+		case ev.Key == termbox.KeyEnter:
+			return game.Event{Action: game.Select}, nil
+
+		default:
+			direction, ok := directionKeys[ev.Key]
+			if !ok {
+				direction, ok = t.diagonals[ev.Ch]
+			}
+
+			if ok {
+				return game.Event{Action: game.Move, Direction: direction}, nil
+			}
+		}
+	}
+}
+
+// Draw displays the provided frame on the terminal.
+func (t *terminal) Draw(frame game.Frame) error {
+	var buttons []button
+
+	switch frame.State {
+	case game.Playing:
+		refreshUI(frame)
+
+	case game.Hiding:
+		drawFloor(frame, hideMsg)
+		setMarker(frame.Target, '#', termbox.ColorRed, termbox.ColorRed)
+
+	default:
+		buttons = interruptUI(frame)
+	}
+
+	t.mu.Lock()
+	t.buttons = buttons
+	t.mu.Unlock()
+
+	return termbox.Flush()
+}
+
+// Start plays the tapoo game on the terminal. If the game cannot be created the terminal
+// is restored and the error is printed before exiting.
+func Start(opts game.Options) {
+	screen, err := newTerminal(opts.Topology)
+
+	if err == nil {
+		err = game.Play(opts, screen)
+		screen.Close()
+	}
+
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// keyColors defines the colors of the keys and their matching doors.
+var keyColors = map[string]termbox.Attribute{
+	"a": termbox.ColorRed,
+	"b": termbox.ColorGreen,
+	"c": termbox.ColorBlue,
+	"d": termbox.ColorMagenta,
+}
+
+// getItemColor returns the color used to print the provided part of the maze.
+// Walls and paths use the default color while items have their own colors.
+func getItemColor(element maze.Element) termbox.Attribute {
+	switch element.Kind {
+	case maze.Coin, maze.Gate:
+		return termbox.ColorYellow
+
+	case maze.TimeBonus:
+		return termbox.ColorWhite | termbox.AttrBold
+
+	case maze.Key:
+		return keyColors[element.Key] | termbox.AttrBold
+
+	case maze.Door:
+		return keyColors[element.Key]
+
+	case maze.Teleporter:
+		return termbox.ColorCyan | termbox.AttrBold
+
+	case maze.Stairs:
+		return termbox.ColorGreen | termbox.AttrBold
+
+	case maze.Crossing:
+		return termbox.ColorCyan
+	}
+
+	return coldef
+}
+
+// fill prints a string to the termbox view box on the given coordinates.
+// Every character takes a single cell regardless of the number of bytes it uses.
+func fill(x, y int, val string, foreground termbox.Attribute) {
+	for index, char := range []rune(val) {
+		termbox.SetCell(x+index, y, char, foreground, coldef)
+	}
+}
+
+// drawMaze draws the provided parts of a maze floor on the termbox view. The number of rows
+// and the number of characters on the longest row are returned.
+func drawMaze(elements []maze.Element) (rows, width int) {
+	if err := termbox.Clear(coldef, coldef); err != nil {
+		panic(err)
+	}
+
+	for _, element := range elements {
+		fill(3+element.X, 7+element.Row, element.Text, getItemColor(element))
+
+		if element.Row+1 > rows {
+			rows = element.Row + 1
+		}
+
+		if end := element.X + utf8.RuneCountInString(element.Text); end > width {
+			width = end
+		}
+	}
+
+	return rows, width
+}
+
+// center returns the terminal column where the provided text starts so that it is centered
+// above a maze of the provided width. Texts longer than the maze start on the left edge.
+func center(width int, text string) int {
+	if x := 3 + (width-utf8.RuneCountInString(text))/2; x > 0 {
+		return x
+	}
+	return 0
+}
+
+// drawFloor draws the parts of the floor in the provided frame on the termbox view together
+// with the header, holding the provided navigation message, and the floor indicator if the
+// maze has several floors. The number of rows and the width of the floor drawn are returned.
+func drawFloor(frame game.Frame, navigation string) (rows, width int) {
+	rows, width = drawMaze(frame.Elements)
+
+	for loc, msg := range map[int]string{1: intro, 2: website, 3: navigation} {
+		fill(center(width, msg), loc, msg, coldef)
+	}
+
+	if frame.Floors > 1 {
+		msg := fmt.Sprintf(floorMsg, frame.Floor+1, frame.Floors)
+		fill(center(width, msg), rows+8, msg, termbox.ColorGreen)
+	}
+
+	return rows, width
+}
+
+// drawHUD draws the level, the moves, the hints, the scores and the best score of the level
+// above a maze of the provided width, followed by the time left as a countdown and a progress
+// bar that changes color as the time runs out.
+func drawHUD(frame game.Frame, width int) {
+	best := "-"
+	if frame.BestScore > 0 {
+		best = fmt.Sprint(frame.BestScore)
+	}
+
+	stats := fmt.Sprintf(hudMsg, frame.Level, frame.Moves, frame.Hints, frame.Scores, best)
+	if frame.Name != "" {
+		stats = fmt.Sprintf(playerMsg, frame.Name) + stats
+	}
+
+	fill(center(width, stats), 4, stats, coldef)
+
+	timeLeft := frame.TimeLeft
+	if timeLeft < 0 {
+		timeLeft = 0
+	}
+
+	// The progress bar takes half the maze width, within the bar size limits.
+	size := width / 2
+	if size < minBarSize {
+		size = minBarSize
+	} else if size > maxBarSize {
+		size = maxBarSize
+	}
+
+	filled, color := size, termbox.ColorGreen
+	if frame.TimeBudget > 0 {
+		filled = size * timeLeft / frame.TimeBudget
+	}
+
+	switch {
+	case filled > size:
+		filled = size
+
+	case filled*5 <= size:
+		color = termbox.ColorRed
+
+	case filled*2 <= size:
+		color = termbox.ColorYellow
+	}
+
+	countdown := fmt.Sprintf(timeMsg, timeLeft/60, timeLeft%60)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", size-filled)
+	x := center(width, countdown+bar)
+
+	fill(x, 5, countdown, coldef)
+	fill(x+utf8.RuneCountInString(countdown), 5, bar, color)
+}
+
+// setMarker draws the provided character on the cell found on the provided row and column
+// of the floor. Nothing is drawn if no position is provided.
+func setMarker(pos []int, char rune, foreground, background termbox.Attribute) {
+	if pos != nil {
+		termbox.SetCell((pos[1]*2)+3, pos[0]+7, char, foreground, background)
+	}
+}
+
+// setTrailMarker draws the provided character on the cell found on the provided row and column
+// of the floor unless it holds an item, so that the trail never covers the stairs or the keys.
+func setTrailMarker(pos []int, char rune, foreground termbox.Attribute) {
+	width, _ := termbox.Size()
+	x, y := (pos[1]*2)+3, pos[0]+7
+
+	if index := y*width + x; x < width && index < len(termbox.CellBuffer()) && termbox.CellBuffer()[index].Ch == ' ' {
+		termbox.SetCell(x, y, char, foreground, coldef)
+	}
+}
+
+// refreshUI refreshes the scores value and update the player positions.
+// Only the part of the floor of the player within the visibility radius of the player is displayed.
+// The trail and the breadcrumbs are drawn above the maze, below the markers of the game pieces.
+func refreshUI(frame game.Frame) {
+	_, width := drawFloor(frame, playerNavigation)
+	drawHUD(frame, width)
+
+	for _, pos := range frame.Trail {
+		setTrailMarker(pos, '·', termbox.ColorBlack|termbox.AttrBold)
+	}
+
+	for _, pos := range frame.Breadcrumbs {
+		setTrailMarker(pos, '*', termbox.ColorGreen)
+	}
+
+	for _, pos := range frame.Hint {
+		setMarker(pos, '.', termbox.ColorYellow, coldef)
+	}
+
+	setMarker(frame.Target, '#', termbox.ColorRed, termbox.ColorRed)
+
+	for _, pos := range frame.Seekers {
+		setMarker(pos, '&', termbox.ColorMagenta, coldef)
+	}
+
+	setMarker(frame.Player, '@', termbox.ColorCyan, termbox.ColorCyan)
+}
+
+// interruptUI displays some text indicating  if the game is paused or
+// after the player won or lost a given tapoo game level. The buttons drawn are returned.
+func interruptUI(frame game.Frame) []button {
+	rows, width := drawFloor(frame, playerNavigation)
+	drawHUD(frame, width)
+
+	interruption := interruptions[frame.State]
+	xAxis := center(width, space)
+
+	for _, loc := range []int{3, 5, 7, 9} {
+		fill(xAxis, rows/2+loc, space, coldef)
+	}
+
+	for loc, msg := range map[int]string{4: interruption.msg, 8: gameOverNavigation} {
+		fill(xAxis, rows/2+loc, msg, coldef)
+	}
+
+	scoresMsg := space
+	switch {
+	case frame.State == game.Paused:
+
+	case frame.Name != "":
+		scoresMsg = fmt.Sprintf(playerScores, frame.Name, frame.Scores, frame.BestScore)
+
+	default:
+		scoresMsg = fmt.Sprintf(highScores, frame.Scores)
+	}
+
+	fill(xAxis, rows/2+6, scoresMsg, interruption.color)
+
+	buttons := []button{
+		{x: xAxis + len(space)/2 - len(proceedButton) - 2, y: rows/2 + 9, text: proceedButton, action: game.Proceed},
+		{x: xAxis + len(space)/2 + 2, y: rows/2 + 9, text: quitButton, action: game.Quit},
+	}
+
+	for _, b := range buttons {
+		fill(b.x, b.y, b.text, interruption.color|termbox.AttrBold)
+	}
+
+	return buttons
+}
@@ -0,0 +1,265 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dmigwi/tapoo/game"
+	"github.com/dmigwi/tapoo/maze"
+)
+
+// frameInterval defines the shortest time between two frames sent to the browser.
+const frameInterval = 50 * time.Millisecond
+
+// actions defines the names of the player actions sent by the browser.
+var actions = map[string]game.Action{
+	"move":    game.Move,
+	"hint":    game.Hint,
+	"pause":   game.Pause,
+	"proceed": game.Proceed,
+	"quit":    game.Quit,
+	"select":  game.Select,
+	"travel":  game.Travel,
+	"drop":    game.Drop,
+}
+
+// request defines a player action sent by the browser, e.g. {"action":"move","direction":"LEFT"}.
+// Row and X hold the character clicked on the floor by the travel action, e.g.
+// {"action":"travel","row":3,"x":6}.
+type request struct {
+	Action    string `json:"action"`
+	Direction string `json:"direction"`
+	Row       int    `json:"row"`
+	X         int    `json:"x"`
+}
+
+// item defines a maze part that the browser draws in its own color. Kind is one of the
+// maze part kinds and Key is the key of the Key and Door parts.
+type item struct {
+	Row  int    `json:"row"`
+	X    int    `json:"x"`
+	Text string `json:"text"`
+	Kind int    `json:"kind"`
+	Key  string `json:"key,omitempty"`
+}
+
+// message defines a frame sent to the browser. Rows holds the text of every row of the floor
+// while Items holds the parts drawn in their own colors. Positions are the rows and the columns
+// of the cells among the maze parts, the character of a cell on a row is found at twice its column.
+// Error is only set when the game cannot be played.
+type message struct {
+	State       int      `json:"state"`
+	Rows        []string `json:"rows"`
+	Items       []item   `json:"items"`
+	Floor       int      `json:"floor"`
+	Floors      int      `json:"floors"`
+	Player      []int    `json:"player"`
+	Target      []int    `json:"target"`
+	Seekers     [][]int  `json:"seekers"`
+	Hint        [][]int  `json:"hint"`
+	Trail       [][]int  `json:"trail"`
+	Breadcrumbs [][]int  `json:"breadcrumbs"`
+	Name        string   `json:"name"`
+	Level       int      `json:"level"`
+	TimeLeft    int      `json:"timeLeft"`
+	TimeBudget  int      `json:"timeBudget"`
+	Moves       int      `json:"moves"`
+	Scores      int      `json:"scores"`
+	BestScore   int      `json:"bestScore"`
+	Hints       int      `json:"hints"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// socket defines the frontend that plays the game in a browser through a WebSocket.
+// Frames are sent at most once every frame interval, only the latest frame drawn is sent.
+type socket struct {
+	ws            *websocket
+	columns, rows int
+
+	mu      sync.Mutex
+	latest  *message
+	err     error
+	pending chan struct{}
+	done    chan struct{}
+}
+
+// newSocket returns the frontend of the provided connection whose browser can display the
+// provided number of columns and rows of text.
+func newSocket(ws *websocket, columns, rows int) *socket {
+	s := &socket{
+		ws:      ws,
+		columns: columns,
+		rows:    rows,
+		pending: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+
+	go s.send()
+
+	return s
+}
+
+// getMessage returns the message sent to the browser for the provided frame.
+func getMessage(frame game.Frame) *message {
+	var text strings.Builder
+
+	msg := &message{
+		State:       int(frame.State),
+		Floor:       frame.Floor,
+		Floors:      frame.Floors,
+		Player:      frame.Player,
+		Target:      frame.Target,
+		Seekers:     frame.Seekers,
+		Hint:        frame.Hint,
+		Trail:       frame.Trail,
+		Breadcrumbs: frame.Breadcrumbs,
+		Name:        frame.Name,
+		Level:       frame.Level,
+		TimeLeft:    frame.TimeLeft,
+		TimeBudget:  frame.TimeBudget,
+		Moves:       frame.Moves,
+		Scores:      frame.Scores,
+		BestScore:   frame.BestScore,
+		Hints:       frame.Hints,
+	}
+
+	for index, element := range frame.Elements {
+		text.WriteString(element.Text)
+
+		if element.Kind != maze.Plain {
+			msg.Items = append(msg.Items, item{element.Row, element.X, element.Text, int(element.Kind), element.Key})
+		}
+
+		if index == len(frame.Elements)-1 || frame.Elements[index+1].Row != element.Row {
+			msg.Rows = append(msg.Rows, text.String())
+			text.Reset()
+		}
+	}
+
+	return msg
+}
+
+// EncodeFrame returns the provided frame as the JSON message drawn by the browser front end.
+func EncodeFrame(frame game.Frame) ([]byte, error) {
+	return json.Marshal(getMessage(frame))
+}
+
+// EncodeError returns the provided error as the JSON message displayed by the browser front end.
+func EncodeError(err error) []byte {
+	data, _ := json.Marshal(message{Error: err.Error()})
+	return data
+}
+
+// DecodeEvent returns the player action held by the provided JSON request sent by the browser
+// front end. If the request is not valid an error is returned.
+func DecodeEvent(data []byte) (game.Event, error) {
+	var req request
+
+	if err := json.Unmarshal(data, &req); err != nil {
+		return game.Event{}, err
+	}
+
+	action, ok := actions[req.Action]
+	if !ok {
+		return game.Event{}, fmt.Errorf("Invalid action found: %s. Allowed move, hint, pause, proceed, quit, select, travel and drop", req.Action)
+	}
+
+	return game.Event{Action: action, Direction: strings.ToUpper(req.Direction), Row: req.Row, X: req.X}, nil
+}
+
+// Size returns the number of columns and rows of text that the browser can display.
+func (s *socket) Size() (columns, rows int) {
+	return s.columns, s.rows
+}
+
+// Draw queues the provided frame to be sent to the browser. The error of the last frame
+// sent is returned.
+func (s *socket) Draw(frame game.Frame) error {
+	msg := getMessage(frame)
+
+	s.mu.Lock()
+	s.latest = msg
+	err := s.err
+	s.mu.Unlock()
+
+	select {
+	case s.pending <- struct{}{}:
+	default:
+	}
+
+	return err
+}
+
+// Poll waits for the next valid player action sent by the browser.
+func (s *socket) Poll() (game.Event, error) {
+	for {
+		data, err := s.ws.ReadMessage()
+		if err != nil {
+			return game.Event{}, err
+		}
+
+		if event, err := DecodeEvent(data); err == nil {
+			return event, nil
+		}
+	}
+}
+
+// sendError sends the provided error to the browser straight away.
+func (s *socket) sendError(err error) error {
+	return s.ws.WriteMessage(EncodeError(err))
+}
+
+// send writes the latest frame drawn to the browser whenever a new frame is drawn. Frames
+// identical to the one sent before are skipped.
+func (s *socket) send() {
+	var previous []byte
+
+	for {
+		select {
+		case <-s.done:
+			return
+
+		case <-s.pending:
+		}
+
+		s.mu.Lock()
+		msg := s.latest
+		s.mu.Unlock()
+
+		data, err := json.Marshal(msg)
+		if err == nil && !bytes.Equal(data, previous) {
+			err = s.ws.WriteMessage(data)
+			previous = data
+		}
+
+		if err != nil {
+			s.mu.Lock()
+			s.err = err
+			s.mu.Unlock()
+			return
+		}
+
+		time.Sleep(frameInterval)
+	}
+}
+
+// Close sends the latest frame drawn and closes the connection.
+func (s *socket) Close() error {
+	close(s.done)
+
+	s.mu.Lock()
+	msg := s.latest
+	s.mu.Unlock()
+
+	if msg != nil {
+		if data, err := json.Marshal(msg); err == nil {
+			s.ws.WriteMessage(data)
+		}
+	}
+
+	return s.ws.Close()
+}
@@ -0,0 +1,82 @@
+package web
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dmigwi/tapoo/game"
+	"github.com/dmigwi/tapoo/maze"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestGetMessage tests the functionality of getMessage
+func TestGetMessage(t *testing.T) {
+	Convey("TestGetMessage: Given a frame, its rows should be joined and its items listed", t, func() {
+		msg := getMessage(game.Frame{
+			State: game.Paused,
+			Elements: []maze.Element{
+				{Row: 0, Column: 0, X: 0, Text: "|"},
+				{Row: 0, Column: 1, X: 1, Text: "---"},
+				{Row: 0, Column: 2, X: 4, Text: "|"},
+				{Row: 1, Column: 0, X: 0, Text: "|"},
+				{Row: 1, Column: 1, X: 1, Text: " a ", Kind: maze.Key, Key: "a"},
+				{Row: 1, Column: 2, X: 4, Text: "|"},
+			},
+			Floors: 1,
+			Player: []int{1, 1},
+			Trail:  [][]int{{1, 1}},
+			Level:  3,
+			Moves:  12,
+			Scores: 900,
+		})
+
+		So(msg.State, ShouldEqual, int(game.Paused))
+		So(msg.Rows, ShouldResemble, []string{"|---|", "| a |"})
+		So(msg.Items, ShouldResemble, []item{{Row: 1, X: 1, Text: " a ", Kind: int(maze.Key), Key: "a"}})
+		So(msg.Player, ShouldResemble, []int{1, 1})
+		So(msg.Trail, ShouldResemble, [][]int{{1, 1}})
+		So(msg.Scores, ShouldEqual, 900)
+		So(msg.Level, ShouldEqual, 3)
+		So(msg.Moves, ShouldEqual, 12)
+	})
+}
+
+// TestEncodeError tests the functionality of EncodeError
+func TestEncodeError(t *testing.T) {
+	Convey("TestEncodeError: Given an error, a message holding only the error should be returned", t, func() {
+		So(string(EncodeError(errors.New("terminal size is too small"))), ShouldEqual,
+			`{"state":0,"rows":null,"items":null,"floor":0,"floors":0,"player":null,"target":null,`+
+				`"seekers":null,"hint":null,"trail":null,"breadcrumbs":null,"name":"","level":0,"timeLeft":0,`+
+				`"timeBudget":0,"moves":0,"scores":0,"bestScore":0,"hints":0,"error":"terminal size is too small"}`)
+	})
+}
+
+// TestDecodeEvent tests the functionality of DecodeEvent
+func TestDecodeEvent(t *testing.T) {
+	Convey("TestDecodeEvent: Given a request sent by the browser", t, func() {
+		Convey("that holds a valid action, the player event should be returned", func() {
+			event, err := DecodeEvent([]byte(`{"action":"move","direction":"left"}`))
+
+			So(err, ShouldBeNil)
+			So(event, ShouldResemble, game.Event{Action: game.Move, Direction: "LEFT"})
+
+			event, err = DecodeEvent([]byte(`{"action":"select"}`))
+
+			So(err, ShouldBeNil)
+			So(event, ShouldResemble, game.Event{Action: game.Select})
+
+			event, err = DecodeEvent([]byte(`{"action":"travel","row":3,"x":6}`))
+
+			So(err, ShouldBeNil)
+			So(event, ShouldResemble, game.Event{Action: game.Travel, Row: 3, X: 6})
+		})
+
+		Convey("that is invalid, an error should be returned", func() {
+			_, err := DecodeEvent([]byte(`{"action":"jump"}`))
+			So(err, ShouldNotBeNil)
+
+			_, err = DecodeEvent([]byte(`move`))
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,104 @@
+package web
+
+import (
+	"embed"
+	"io/fs"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/dmigwi/tapoo/game"
+	"github.com/dmigwi/tapoo/profile"
+)
+
+const (
+	// defaultColumns and defaultRows define the text size used when the browser does not send its own.
+	defaultColumns, defaultRows = 120, 40
+
+	// maxColumns and maxRows define the largest text size accepted from the browser.
+	maxColumns, maxRows = 400, 200
+)
+
+// static holds the HTML and JavaScript front end served to the browser.
+//
+//go:embed static
+var static embed.FS
+
+// getDimension returns the positive number held by the provided query value if it does
+// not exceed the provided maximum. Otherwise the provided default value is returned.
+func getDimension(value string, fallback, maximum int) int {
+	if number, err := strconv.Atoi(value); err == nil && number > 0 {
+		if number > maximum {
+			return maximum
+		}
+		return number
+	}
+
+	return fallback
+}
+
+// getPlayerOptions returns the provided game options played by the player with the provided
+// name. The results of the player are recorded in the provided profiles, if any. Invalid names
+// play without a name.
+func getPlayerOptions(opts game.Options, name string, profiles *profile.Store) game.Options {
+	name, err := profile.CheckName(name)
+	if err != nil {
+		return opts
+	}
+
+	opts.Player = name
+
+	if profiles != nil {
+		opts.BestScores = profiles.Profile(name).BestScores()
+		opts.Record = func(result game.Result) {
+			if err := profiles.Record(result); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+
+	return opts
+}
+
+// NewHandler returns the handler serving the front end and the games played through it.
+// Every WebSocket connection to /play plays its own game using the provided options.
+// The browser sends the number of columns and rows of text it can display and the name of
+// the player in the query. The results of every player are recorded in the provided profiles,
+// nil records nothing.
+func NewHandler(opts game.Options, profiles *profile.Store) http.Handler {
+	mux := http.NewServeMux()
+
+	files, err := fs.Sub(static, "static")
+	if err != nil {
+		panic(err)
+	}
+
+	mux.Handle("/", http.FileServer(http.FS(files)))
+
+	mux.HandleFunc("/play", func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrade(w, r)
+		if err != nil {
+			log.Println(err)
+			return
+		}
+
+		frontend := newSocket(ws,
+			getDimension(r.URL.Query().Get("columns"), defaultColumns, maxColumns),
+			getDimension(r.URL.Query().Get("rows"), defaultRows, maxRows))
+
+		if err = game.Play(getPlayerOptions(opts, r.URL.Query().Get("name"), profiles), frontend); err != nil {
+			frontend.sendError(err)
+		}
+
+		frontend.Close()
+	})
+
+	return mux
+}
+
+// Serve plays the tapoo game in the browsers that connect to the provided address,
+// e.g. :8080, using the provided options and recording the results in the provided profiles.
+func Serve(listen string, opts game.Options, profiles *profile.Store) error {
+	log.Printf("Play tapoo on http://%s", listen)
+	return http.ListenAndServe(listen, NewHandler(opts, profiles))
+}
@@ -0,0 +1,153 @@
+package web
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/dmigwi/tapoo/game"
+	"github.com/dmigwi/tapoo/profile"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestGetDimension tests the functionality of getDimension
+func TestGetDimension(t *testing.T) {
+	Convey("TestGetDimension: Given a query value, a positive number within the maximum should be returned", t, func() {
+		So(getDimension("80", 120, 400), ShouldEqual, 80)
+		So(getDimension("800", 120, 400), ShouldEqual, 400)
+		So(getDimension("-3", 120, 400), ShouldEqual, 120)
+		So(getDimension("", 120, 400), ShouldEqual, 120)
+	})
+}
+
+// TestGetPlayerOptions tests the functionality of getPlayerOptions
+func TestGetPlayerOptions(t *testing.T) {
+	Convey("TestGetPlayerOptions: Given the name sent by the browser", t, func() {
+		profiles, err := profile.Open(filepath.Join(t.TempDir(), "profiles.json"))
+		So(err, ShouldBeNil)
+
+		So(profiles.Record(game.Result{Player: "Ann", Level: 1, State: game.Won, Scores: 700}), ShouldBeNil)
+
+		Convey("that is valid, the game should record the results of the player", func() {
+			opts := getPlayerOptions(game.Options{Difficulty: "easy"}, " Ann ", profiles)
+
+			So(opts.Player, ShouldEqual, "Ann")
+			So(opts.BestScores, ShouldResemble, map[int]int{1: 700})
+
+			opts.Record(game.Result{Player: "Ann", Level: 1, State: game.Lost})
+			So(profiles.Profile("Ann").Losses, ShouldEqual, 1)
+		})
+
+		Convey("that is invalid, the game should be played without a name", func() {
+			opts := getPlayerOptions(game.Options{Difficulty: "easy"}, "", profiles)
+
+			So(opts.Player, ShouldBeEmpty)
+			So(opts.Record, ShouldBeNil)
+		})
+	})
+}
+
+// TestNewHandler tests the functionality of NewHandler
+func TestNewHandler(t *testing.T) {
+	Convey("TestNewHandler: Given the game options", t, func() {
+		server := httptest.NewServer(NewHandler(game.Options{Difficulty: "easy"}, nil))
+		defer server.Close()
+
+		Convey("the front end should be served", func() {
+			resp, err := http.Get(server.URL)
+			So(err, ShouldBeNil)
+
+			page, err := io.ReadAll(resp.Body)
+
+			So(err, ShouldBeNil)
+			So(string(page), ShouldContainSubstring, `<canvas id="maze">`)
+		})
+
+		Convey("a game should be played through the WebSocket", func() {
+			var frame message
+
+			conn, reader, err := dialSocket(server, "/play?columns=120&rows=40")
+			So(err, ShouldBeNil)
+			defer conn.Close()
+
+			_, payload, err := readServerFrame(reader)
+
+			So(err, ShouldBeNil)
+			So(json.Unmarshal(payload, &frame), ShouldBeNil)
+			So(frame.State, ShouldEqual, int(game.Playing))
+			So(frame.Rows, ShouldNotBeEmpty)
+			So(frame.Player, ShouldHaveLength, 2)
+
+			So(writeClientFrame(conn, textFrame, true, []byte(`{"action":"pause"}`)), ShouldBeNil)
+
+			for frame.State != int(game.Paused) {
+				_, payload, err = readServerFrame(reader)
+				So(err, ShouldBeNil)
+				So(json.Unmarshal(payload, &frame), ShouldBeNil)
+			}
+
+			// The game ends once the player quits after pausing it.
+			So(writeClientFrame(conn, textFrame, true, []byte(`{"action":"quit"}`)), ShouldBeNil)
+
+			for err == nil {
+				_, _, err = readServerFrame(reader)
+			}
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("several games should be played at once", func() {
+			var (
+				wg     sync.WaitGroup
+				frames = make([]message, 2)
+				errors = make([]error, len(frames))
+			)
+
+			for i := range frames {
+				wg.Add(1)
+
+				go func(i int) {
+					defer wg.Done()
+
+					conn, reader, err := dialSocket(server, "/play?columns=120&rows=40")
+					if err != nil {
+						errors[i] = err
+						return
+					}
+					defer conn.Close()
+
+					_, payload, err := readServerFrame(reader)
+					if err == nil {
+						err = json.Unmarshal(payload, &frames[i])
+					}
+					errors[i] = err
+				}(i)
+			}
+
+			wg.Wait()
+
+			for i, frame := range frames {
+				So(errors[i], ShouldBeNil)
+				So(frame.State, ShouldEqual, int(game.Playing))
+				So(frame.Rows, ShouldNotBeEmpty)
+			}
+		})
+
+		Convey("a game that cannot be created should send its error", func() {
+			var frame message
+
+			conn, reader, err := dialSocket(server, "/play?columns=10&rows=10")
+			So(err, ShouldBeNil)
+			defer conn.Close()
+
+			_, payload, err := readServerFrame(reader)
+
+			So(err, ShouldBeNil)
+			So(json.Unmarshal(payload, &frame), ShouldBeNil)
+			So(frame.Error, ShouldContainSubstring, "terminal size is too small")
+		})
+	})
+}
@@ -0,0 +1,244 @@
+"use strict";
+
+// Tapoo draws the frames sent by the game engine on a canvas and sends the player actions back
+// to it. The engine either runs on the server, see index.html, or in the page, see wasm.html.
+const Tapoo = (() => {
+  // The kinds of the maze parts, in the same order as the maze package.
+  const Kind = { Plain: 0, Coin: 1, TimeBonus: 2, Key: 3, Door: 4, Gate: 5, Teleporter: 6, Stairs: 7, Crossing: 8 };
+
+  // The states of the frames, in the same order as the game package.
+  const State = { Playing: 0, Hiding: 1, Paused: 2, Won: 3, Lost: 4, Beaten: 5 };
+
+  const messages = {
+    [State.Hiding]: "Move the target (in red) and press Enter to hide it",
+    [State.Paused]: "Game Paused !!!   Press Space or P to resume, Esc to quit",
+    [State.Won]: "Game Over! Congratulations, you located the target on time.",
+    [State.Lost]: "Game Over! Ooops!!! You failed to locate the target on time.",
+    [State.Beaten]: "Game Over! Ooops!!! A computer seeker located the target first.",
+  };
+
+  const colors = {
+    text: "#d4d4d4", coin: "#e5c07b", bonus: "#ffffff", teleporter: "#56b6c2", stairs: "#98c379",
+    player: "#61afef", target: "#e06c75", seeker: "#c678dd", hint: "#e5c07b", trail: "#2c313a", breadcrumb: "#98c379",
+    keys: { a: "#e06c75", b: "#98c379", c: "#61afef", d: "#c678dd" },
+  };
+
+  const keys = {
+    ArrowLeft: ["move", "LEFT"], ArrowRight: ["move", "RIGHT"], ArrowUp: ["move", "UP"], ArrowDown: ["move", "DOWN"],
+    PageUp: ["move", "ABOVE"], PageDown: ["move", "BELOW"],
+    q: ["move", "UPLEFT"], e: ["move", "UPRIGHT"], a: ["move", "DOWNLEFT"], d: ["move", "DOWNRIGHT"],
+    h: ["hint"], b: ["drop"], " ": ["pause"], Enter: ["select"], p: ["proceed"], Escape: ["quit"],
+  };
+
+  // The buttons drawn below the maze once the game stops.
+  const buttons = [{ x: 0, text: "[ Proceed ]", action: "proceed" }, { x: 14, text: "[ Quit ]", action: "quit" }];
+
+  const fontSize = 16;
+
+  // createBoard returns the board drawing the frames on the provided canvas. Columns and rows are
+  // the amount of text that fits in the window. The game keeps ten rows for its own messages while
+  // the page only needs three, so the extra rows are added back.
+  function createBoard(canvas) {
+    const context = canvas.getContext("2d");
+
+    context.font = fontSize + "px monospace";
+    const charWidth = context.measureText("M").width;
+    const lineHeight = Math.ceil(fontSize * 1.25);
+
+    // The rows of the maze and the row of the buttons in the latest frame drawn, -1 hides the buttons.
+    let mazeRows = 0, buttonRow = -1;
+
+    function itemColor(item) {
+      switch (item.kind) {
+        case Kind.Coin: case Kind.Gate: return colors.coin;
+        case Kind.TimeBonus: return colors.bonus;
+        case Kind.Key: case Kind.Door: return colors.keys[item.key];
+        case Kind.Teleporter: case Kind.Crossing: return colors.teleporter;
+        case Kind.Stairs: return colors.stairs;
+      }
+      return colors.text;
+    }
+
+    function write(x, y, text, color) {
+      context.fillStyle = color;
+      Array.from(text).forEach((char, index) => {
+        context.fillText(char, (x + index) * charWidth, (y + 1) * lineHeight - 4);
+      });
+    }
+
+    function mark(pos, char, color, filled) {
+      if (!pos) {
+        return;
+      }
+
+      const x = pos[1] * 2, y = pos[0];
+
+      if (filled) {
+        context.fillStyle = color;
+        context.fillRect(x * charWidth, y * lineHeight, charWidth, lineHeight);
+      }
+
+      write(x, y, char, filled ? "#1e1e1e" : color);
+    }
+
+    // writeCentered writes the provided text centered below a maze of the provided width.
+    function writeCentered(width, y, text, color) {
+      write(Math.max(0, Math.floor((width - text.length) / 2)), y, text, color);
+    }
+
+    // drawHUD writes the level, the moves, the hints, the scores and the best score of the level
+    // on the provided row, followed by the time left as a countdown and a progress bar that takes
+    // half the maze width and changes color as the time runs out.
+    function drawHUD(frame, y, width) {
+      let stats = (frame.name ? "Player: " + frame.name + "   " : "") + "Level " + frame.level + "   Moves: " + frame.moves + "   Hints: " + frame.hints +
+        "   Scores: " + frame.scores + "   Best: " + (frame.bestScore > 0 ? frame.bestScore : "-");
+
+      if (frame.floors > 1) {
+        stats += "   Floor " + (frame.floor + 1) + " of " + frame.floors;
+      }
+
+      writeCentered(width, y, stats, colors.text);
+
+      const timeLeft = Math.max(0, frame.timeLeft);
+      const ratio = frame.timeBudget > 0 ? Math.min(1, timeLeft / frame.timeBudget) : 1;
+      const countdown = "Time " + Math.floor(timeLeft / 60) + ":" + String(timeLeft % 60).padStart(2, "0") + "  ";
+      const size = Math.min(40, Math.max(10, Math.floor(width / 2)));
+      const x = Math.max(0, Math.floor((width - countdown.length - size) / 2));
+
+      write(x, y + 1, countdown, colors.text);
+
+      const left = (x + countdown.length) * charWidth, top = (y + 1) * lineHeight + 4;
+      context.fillStyle = colors.trail;
+      context.fillRect(left, top, size * charWidth, lineHeight - 8);
+      context.fillStyle = ratio <= 0.2 ? colors.target : ratio <= 0.5 ? colors.coin : colors.stairs;
+      context.fillRect(left, top, Math.round(size * ratio) * charWidth, lineHeight - 8);
+    }
+
+    function draw(frame) {
+      const width = Math.max(...frame.rows.map((row) => Array.from(row).length), 60);
+
+      canvas.width = width * charWidth;
+      canvas.height = (frame.rows.length + 5) * lineHeight;
+      context.font = fontSize + "px monospace";
+      context.clearRect(0, 0, canvas.width, canvas.height);
+
+      // The trail shades the visited cells behind the maze text.
+      context.fillStyle = colors.trail;
+      (frame.trail || []).forEach((pos) => {
+        context.fillRect(pos[1] * 2 * charWidth, pos[0] * lineHeight, charWidth, lineHeight);
+      });
+
+      frame.rows.forEach((row, y) => write(0, y, row, colors.text));
+      (frame.items || []).forEach((item) => {
+        context.clearRect(item.x * charWidth, item.row * lineHeight, Array.from(item.text).length * charWidth, lineHeight);
+        write(item.x, item.row, item.text, itemColor(item));
+      });
+
+      (frame.breadcrumbs || []).forEach((pos) => mark(pos, "*", colors.breadcrumb, false));
+      (frame.hint || []).forEach((pos) => mark(pos, ".", colors.hint, false));
+      mark(frame.target, "#", colors.target, true);
+      (frame.seekers || []).forEach((pos) => mark(pos, "&", colors.seeker, false));
+      mark(frame.player, "@", colors.player, true);
+
+      const status = frame.rows.length + 1;
+      const mazeWidth = Math.max(...frame.rows.map((row) => Array.from(row).length));
+
+      if (frame.state !== State.Hiding) {
+        drawHUD(frame, status, mazeWidth);
+      } else if (frame.floors > 1) {
+        writeCentered(mazeWidth, status, "Floor " + (frame.floor + 1) + " of " + frame.floors, colors.stairs);
+      }
+
+      if (messages[frame.state]) {
+        writeCentered(mazeWidth, status + 2, messages[frame.state], frame.state === State.Hiding ? colors.text : colors.target);
+      }
+
+      mazeRows = frame.rows.length;
+      buttonRow = frame.state === State.Playing || frame.state === State.Hiding ? -1 : status + 3;
+
+      if (buttonRow >= 0) {
+        buttons.forEach((button) => write(button.x, buttonRow, button.text, colors.player));
+      }
+    }
+
+    // click returns the player action selected by a mouse click on the canvas. Clicks on the
+    // buttons select their actions while clicks on the maze travel to the character clicked.
+    function click(event) {
+      const bounds = canvas.getBoundingClientRect();
+      const x = Math.floor((event.clientX - bounds.left) / charWidth);
+      const row = Math.floor((event.clientY - bounds.top) / lineHeight);
+
+      if (row === buttonRow) {
+        const button = buttons.find((b) => x >= b.x && x < b.x + b.text.length);
+        return button ? { action: button.action } : null;
+      }
+
+      return row < mazeRows ? { action: "travel", row: row, x: x } : null;
+    }
+
+    function showError(text) {
+      canvas.width = 80 * charWidth;
+      canvas.height = 2 * lineHeight;
+      mazeRows = 0;
+      buttonRow = -1;
+      context.font = fontSize + "px monospace";
+      write(0, 0, text, colors.target);
+    }
+
+    // show draws the frame or displays the error held by the provided JSON message.
+    function show(data) {
+      const frame = JSON.parse(data);
+
+      if (frame.error) {
+        showError(frame.error);
+      } else {
+        draw(frame);
+      }
+    }
+
+    return {
+      columns: Math.floor((window.innerWidth - 32) / charWidth),
+      rows: Math.floor((window.innerHeight - 60) / lineHeight) + 7,
+      canvas: canvas,
+      show: show,
+      click: click,
+    };
+  }
+
+  // listen sends the player actions as JSON, e.g. {"action":"move","direction":"LEFT"}, to the
+  // provided function whenever a key mapped to an action is pressed or the provided board is clicked.
+  function listen(send, board) {
+    board.canvas.addEventListener("click", (event) => {
+      const request = board.click(event);
+
+      if (request) {
+        send(JSON.stringify(request));
+      }
+    });
+
+    document.addEventListener("keydown", (event) => {
+      const key = keys[event.key] || keys[event.key.toLowerCase()];
+
+      if (key) {
+        event.preventDefault();
+        send(JSON.stringify({ action: key[0], direction: key[1] || "" }));
+      }
+    });
+  }
+
+  // playerName returns the name of the player, it is only asked once and then kept by the browser.
+  function playerName() {
+    let name = localStorage.getItem("tapoo-player");
+
+    if (!name) {
+      name = (prompt("Enter your player name") || "").trim();
+      if (name) {
+        localStorage.setItem("tapoo-player", name);
+      }
+    }
+
+    return name;
+  }
+
+  return { createBoard: createBoard, listen: listen, playerName: playerName };
+})();
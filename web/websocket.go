@@ -0,0 +1,251 @@
+package web
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// websocketGUID defines the value appended to the key of the client to accept the connection.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxMessageSize defines the largest message accepted from the client in bytes.
+const maxMessageSize = 1 << 16
+
+const (
+	continuationFrame = 0x0
+	textFrame         = 0x1
+	closeFrame        = 0x8
+	pingFrame         = 0x9
+	pongFrame         = 0xA
+)
+
+// errClosed is returned after the client closes the connection.
+var errClosed = errors.New("websocket: connection closed")
+
+// websocket defines a connection upgraded to the WebSocket protocol. Only text messages
+// are supported, which is all the web frontend needs.
+type websocket struct {
+	conn   net.Conn
+	reader *bufio.Reader
+
+	// writing prevents messages written at the same time from mixing together.
+	writing sync.Mutex
+}
+
+// getAcceptKey returns the key that proves to the client that the connection was accepted.
+func getAcceptKey(key string) string {
+	hash := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// headerContains checks if the provided header holds the provided token in its comma
+// separated values, ignoring the case.
+func headerContains(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isSameOrigin checks if the page that opened the provided request was served by the same host.
+// Browsers always send the origin of the page, so requests without one come from other clients.
+func isSameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	page, err := url.Parse(origin)
+
+	return err == nil && strings.EqualFold(page.Host, r.Host)
+}
+
+// upgrade switches the provided request to the WebSocket protocol. If the request is not a
+// valid WebSocket handshake or comes from a page served by another host, an error response
+// is written and the error is returned.
+func upgrade(w http.ResponseWriter, r *http.Request) (*websocket, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+
+	switch {
+	case r.Method != http.MethodGet, !headerContains(r.Header, "Connection", "upgrade"),
+		!headerContains(r.Header, "Upgrade", "websocket"), key == "":
+		http.Error(w, "websocket handshake expected", http.StatusBadRequest)
+		return nil, fmt.Errorf("Invalid websocket handshake found from %s", r.RemoteAddr)
+
+	case r.Header.Get("Sec-WebSocket-Version") != "13":
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported websocket version", http.StatusUpgradeRequired)
+		return nil, fmt.Errorf("Invalid websocket version found: %s. Allowed 13", r.Header.Get("Sec-WebSocket-Version"))
+
+	case !isSameOrigin(r):
+		http.Error(w, "websocket origin not allowed", http.StatusForbidden)
+		return nil, fmt.Errorf("Invalid websocket origin found: %s. Allowed %s", r.Header.Get("Origin"), r.Host)
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return nil, errors.New("websocket: the response cannot be hijacked")
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Accept: %s\r\n\r\n", getAcceptKey(key))
+
+	if err = rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &websocket{conn: conn, reader: rw.Reader}, nil
+}
+
+// readFrame reads a single frame and returns its opcode, whether it is the last frame of
+// its message and its unmasked payload. Frames from the client must be masked.
+func (ws *websocket) readFrame() (opcode byte, final bool, payload []byte, err error) {
+	var header [2]byte
+
+	if _, err = io.ReadFull(ws.reader, header[:]); err != nil {
+		return 0, false, nil, err
+	}
+
+	final, opcode = header[0]&0x80 != 0, header[0]&0x0F
+	length := uint64(header[1] & 0x7F)
+
+	if header[1]&0x80 == 0 {
+		return 0, false, nil, errors.New("websocket: unmasked frame received from the client")
+	}
+
+	switch length {
+	case 126:
+		var extended [2]byte
+		_, err = io.ReadFull(ws.reader, extended[:])
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+
+	case 127:
+		var extended [8]byte
+		_, err = io.ReadFull(ws.reader, extended[:])
+		length = binary.BigEndian.Uint64(extended[:])
+	}
+
+	if err != nil {
+		return 0, false, nil, err
+	}
+
+	if length > maxMessageSize {
+		return 0, false, nil, fmt.Errorf("websocket: frame of %d bytes is larger than %d bytes", length, maxMessageSize)
+	}
+
+	var mask [4]byte
+	if _, err = io.ReadFull(ws.reader, mask[:]); err != nil {
+		return 0, false, nil, err
+	}
+
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(ws.reader, payload); err != nil {
+		return 0, false, nil, err
+	}
+
+	for index := range payload {
+		payload[index] ^= mask[index%4]
+	}
+
+	return opcode, final, payload, nil
+}
+
+// ReadMessage returns the next text message sent by the client. Pings are answered while
+// waiting for it. Once the client closes the connection errClosed is returned.
+func (ws *websocket) ReadMessage() ([]byte, error) {
+	var message []byte
+
+	for {
+		opcode, final, payload, err := ws.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case closeFrame:
+			ws.writeFrame(closeFrame, payload)
+			return nil, errClosed
+
+		case pingFrame:
+			if err = ws.writeFrame(pongFrame, payload); err != nil {
+				return nil, err
+			}
+			continue
+
+		case pongFrame:
+			continue
+
+		case textFrame, continuationFrame:
+			message = append(message, payload...)
+
+		default:
+			return nil, fmt.Errorf("websocket: unsupported opcode %d received", opcode)
+		}
+
+		if len(message) > maxMessageSize {
+			return nil, fmt.Errorf("websocket: message larger than %d bytes received", maxMessageSize)
+		}
+
+		if final {
+			return message, nil
+		}
+	}
+}
+
+// WriteMessage sends the provided text message to the client.
+func (ws *websocket) WriteMessage(message []byte) error {
+	return ws.writeFrame(textFrame, message)
+}
+
+// writeFrame sends a single unmasked frame, as the server frames must not be masked.
+func (ws *websocket) writeFrame(opcode byte, payload []byte) error {
+	header := []byte{0x80 | opcode, 0}
+
+	switch length := len(payload); {
+	case length < 126:
+		header[1] = byte(length)
+
+	case length <= 0xFFFF:
+		header[1] = 126
+		header = append(header, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(length))
+
+	default:
+		header[1] = 127
+		header = append(header, make([]byte, 8)...)
+		binary.BigEndian.PutUint64(header[2:], uint64(length))
+	}
+
+	ws.writing.Lock()
+	defer ws.writing.Unlock()
+
+	_, err := ws.conn.Write(append(header, payload...))
+
+	return err
+}
+
+// Close closes the connection.
+func (ws *websocket) Close() error {
+	return ws.conn.Close()
+}
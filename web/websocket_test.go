@@ -0,0 +1,234 @@
+package web
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// dialSocket opens a WebSocket connection to the provided path of the test server.
+func dialSocket(server *httptest.Server, path string) (net.Conn, *bufio.Reader, error) {
+	return dialSocketFrom(server, path, "")
+}
+
+// dialSocketFrom opens a WebSocket connection to the provided path of the test server as a page
+// served from the provided origin does. No origin is sent if it is empty.
+func dialSocketFrom(server *httptest.Server, path, origin string) (net.Conn, *bufio.Reader, error) {
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if origin != "" {
+		origin = "Origin: " + origin + "\r\n"
+	}
+
+	fmt.Fprintf(conn, "GET %s HTTP/1.1\r\nHost: tapoo\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n%s"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n", path, origin)
+
+	reader := bufio.NewReader(conn)
+
+	resp, err := http.ReadResponse(reader, nil)
+	if err == nil && resp.StatusCode != http.StatusSwitchingProtocols {
+		err = fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return conn, reader, err
+}
+
+// writeClientFrame writes a masked frame as a browser does.
+func writeClientFrame(conn net.Conn, opcode byte, final bool, payload []byte) error {
+	var (
+		header = []byte{opcode, 0x80}
+		mask   = []byte{1, 2, 3, 4}
+		masked = make([]byte, len(payload))
+	)
+
+	if final {
+		header[0] |= 0x80
+	}
+
+	switch {
+	case len(payload) < 126:
+		header[1] |= byte(len(payload))
+
+	case len(payload) <= 0xFFFF:
+		header[1] |= 126
+		header = append(header, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(len(payload)))
+
+	default:
+		header[1] |= 127
+		header = append(header, make([]byte, 8)...)
+		binary.BigEndian.PutUint64(header[2:], uint64(len(payload)))
+	}
+
+	for index := range payload {
+		masked[index] = payload[index] ^ mask[index%4]
+	}
+
+	_, err := conn.Write(append(append(header, mask...), masked...))
+
+	return err
+}
+
+// readServerFrame reads an unmasked frame sent by the server.
+func readServerFrame(reader *bufio.Reader) (byte, []byte, error) {
+	var header [2]byte
+
+	if _, err := io.ReadFull(reader, header[:]); err != nil {
+		return 0, nil, err
+	}
+
+	length := uint64(header[1] & 0x7F)
+
+	switch length {
+	case 126:
+		var extended [2]byte
+		io.ReadFull(reader, extended[:])
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+
+	case 127:
+		var extended [8]byte
+		io.ReadFull(reader, extended[:])
+		length = binary.BigEndian.Uint64(extended[:])
+	}
+
+	payload := make([]byte, length)
+	_, err := io.ReadFull(reader, payload)
+
+	return header[0] & 0x0F, payload, err
+}
+
+// echoServer returns a test server that sends back every message received.
+func echoServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrade(w, r)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+
+		for {
+			message, err := ws.ReadMessage()
+			if err != nil {
+				return
+			}
+			ws.WriteMessage(message)
+		}
+	}))
+}
+
+// TestGetAcceptKey tests the functionality of getAcceptKey
+func TestGetAcceptKey(t *testing.T) {
+	Convey("TestGetAcceptKey: Given the key of the client, the accept key should be returned", t, func() {
+		So(getAcceptKey("dGhlIHNhbXBsZSBub25jZQ=="), ShouldEqual, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=")
+	})
+}
+
+// TestUpgrade tests the functionality of upgrade
+func TestUpgrade(t *testing.T) {
+	Convey("TestUpgrade: Given a request", t, func() {
+		server := echoServer()
+		defer server.Close()
+
+		Convey("that is not a WebSocket handshake, a bad request response should be returned", func() {
+			resp, err := http.Get(server.URL)
+
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("that is a WebSocket handshake, the connection should be accepted", func() {
+			conn, _, err := dialSocket(server, "/")
+
+			So(err, ShouldBeNil)
+			conn.Close()
+		})
+
+		Convey("that is a WebSocket handshake from a page of the same host, the connection should be accepted", func() {
+			conn, _, err := dialSocketFrom(server, "/", "http://tapoo")
+
+			So(err, ShouldBeNil)
+			conn.Close()
+		})
+
+		Convey("that is a WebSocket handshake from a page of another host, a forbidden response should be returned", func() {
+			conn, _, err := dialSocketFrom(server, "/", "http://example.com")
+
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "403")
+			conn.Close()
+		})
+	})
+}
+
+// TestReadMessage tests the functionality of ReadMessage and WriteMessage
+func TestReadMessage(t *testing.T) {
+	Convey("TestReadMessage: Given the frames sent by the client", t, func() {
+		server := echoServer()
+		defer server.Close()
+
+		conn, reader, err := dialSocket(server, "/")
+		So(err, ShouldBeNil)
+		defer conn.Close()
+
+		Convey("of a message split into fragments, the whole message should be read", func() {
+			So(writeClientFrame(conn, textFrame, false, []byte("hello ")), ShouldBeNil)
+			So(writeClientFrame(conn, continuationFrame, true, []byte("tapoo")), ShouldBeNil)
+
+			opcode, payload, err := readServerFrame(reader)
+
+			So(err, ShouldBeNil)
+			So(opcode, ShouldEqual, textFrame)
+			So(string(payload), ShouldEqual, "hello tapoo")
+		})
+
+		Convey("of long messages, their extended lengths should be used", func() {
+			for _, size := range []int{300, 0x10000 - 1} {
+				So(writeClientFrame(conn, textFrame, true, []byte(strings.Repeat("x", size))), ShouldBeNil)
+
+				_, payload, err := readServerFrame(reader)
+
+				So(err, ShouldBeNil)
+				So(payload, ShouldHaveLength, size)
+			}
+		})
+
+		Convey("of a ping, a pong with the same payload should be returned", func() {
+			So(writeClientFrame(conn, pingFrame, true, []byte("ping")), ShouldBeNil)
+
+			opcode, payload, err := readServerFrame(reader)
+
+			So(err, ShouldBeNil)
+			So(opcode, ShouldEqual, pongFrame)
+			So(string(payload), ShouldEqual, "ping")
+		})
+
+		Convey("of a close, the connection should be closed", func() {
+			So(writeClientFrame(conn, closeFrame, true, nil), ShouldBeNil)
+
+			opcode, _, err := readServerFrame(reader)
+			So(err, ShouldBeNil)
+			So(opcode, ShouldEqual, closeFrame)
+
+			_, _, err = readServerFrame(reader)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("of a message that is too large, the connection should be closed", func() {
+			So(writeClientFrame(conn, textFrame, true, []byte(strings.Repeat("x", maxMessageSize+1))), ShouldBeNil)
+
+			_, _, err := readServerFrame(reader)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}